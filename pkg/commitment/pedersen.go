@@ -0,0 +1,45 @@
+// Package commitment 提供独立于 VSS 的 Pedersen 承诺 C = v·G + r·H。
+//
+// 在 log_G(H) 未知的前提下（例如 H 由 ec.DeriveH 派生），承诺是完美隐藏、计算绑定的；
+// 承诺之间可以直接相加，得到对 v1+v2、r1+r2 的承诺。
+package commitment
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"tss-crypto/pkg/ec"
+)
+
+// Commit 计算 C = v·G + r·H，v、r 按 mod N（曲线阶）解释。
+// curve、v、r 或 H 为 nil，或 H 不在 curve 上时返回 nil
+func Commit(curve elliptic.Curve, v, r *big.Int, H *ec.Point) *ec.Point {
+	if curve == nil || v == nil || r == nil || H == nil || H.Curve == nil {
+		return nil
+	}
+	if !ec.SameCurve(curve, H.Curve) {
+		return nil
+	}
+	return ec.ScalarBaseMult(curve, v).Add(H.ScalarMult(r))
+}
+
+// Open 检查 (v, r) 是否打开承诺 C，即 C == v·G + r·H；曲线取自 C
+func Open(C *ec.Point, v, r *big.Int, H *ec.Point) bool {
+	if C == nil || C.Curve == nil {
+		return false
+	}
+	expected := Commit(C.Curve, v, r, H)
+	if expected == nil {
+		return false
+	}
+	return C.Equal(expected)
+}
+
+// Add 同态组合两个承诺：Commit(v1, r1) + Commit(v2, r2) = Commit(v1+v2, r1+r2)。
+// 任一为 nil 或不在同一曲线上时返回 nil
+func Add(C1, C2 *ec.Point) *ec.Point {
+	if C1 == nil || C2 == nil {
+		return nil
+	}
+	return C1.Add(C2)
+}
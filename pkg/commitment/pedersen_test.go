@@ -0,0 +1,111 @@
+package commitment
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"tss-crypto/pkg/ec"
+)
+
+var testCurves = []elliptic.Curve{
+	elliptic.P224(),
+	elliptic.P256(),
+	elliptic.P384(),
+	elliptic.P521(),
+}
+
+func TestPedersen(t *testing.T) {
+	for _, curve := range testCurves {
+		t.Run(curve.Params().Name, func(t *testing.T) {
+			H, err := ec.DeriveH(curve)
+			if err != nil {
+				t.Fatalf("DeriveH 失败: %v", err)
+			}
+			randScalar := func() *big.Int {
+				k, err := ec.RandScalar(curve, rand.Reader)
+				if err != nil {
+					t.Fatalf("生成标量失败: %v", err)
+				}
+				return k
+			}
+
+			v, r := randScalar(), randScalar()
+			C := Commit(curve, v, r, H)
+			if C == nil || !C.IsOnCurve() {
+				t.Fatal("承诺应该是曲线上的点")
+			}
+
+			t.Run("正确打开", func(t *testing.T) {
+				if !Open(C, v, r, H) {
+					t.Error("正确的 (v, r) 应该能打开承诺")
+				}
+			})
+
+			t.Run("错误打开被拒绝", func(t *testing.T) {
+				one := big.NewInt(1)
+				if Open(C, new(big.Int).Add(v, one), r, H) {
+					t.Error("错误的 v 不应该打开承诺")
+				}
+				if Open(C, v, new(big.Int).Add(r, one), H) {
+					t.Error("错误的 r 不应该打开承诺")
+				}
+				// 交换 G、H 的角色
+				G := ec.ScalarBaseMult(curve, one)
+				if Open(C, r, v, G) {
+					t.Error("换用其他生成元不应该打开承诺")
+				}
+			})
+
+			t.Run("同态加法", func(t *testing.T) {
+				v2, r2 := randScalar(), randScalar()
+				C2 := Commit(curve, v2, r2, H)
+				sum := Add(C, C2)
+				if sum == nil {
+					t.Fatal("承诺之和不应该为 nil")
+				}
+				vSum := new(big.Int).Add(v, v2)
+				rSum := new(big.Int).Add(r, r2)
+				if !sum.Equal(Commit(curve, vSum, rSum, H)) {
+					t.Error("承诺之和应该等于和的承诺")
+				}
+				if !Open(sum, vSum, rSum, H) {
+					t.Error("承诺之和应该能用 (v1+v2, r1+r2) 打开")
+				}
+			})
+
+			t.Run("相同的值不同的随机数得到不同的承诺", func(t *testing.T) {
+				if C.Equal(Commit(curve, v, randScalar(), H)) {
+					t.Error("随机数不同时承诺应该不同")
+				}
+			})
+		})
+	}
+
+	t.Run("非法参数", func(t *testing.T) {
+		curve := elliptic.P256()
+		H, err := ec.DeriveH(curve)
+		if err != nil {
+			t.Fatalf("DeriveH 失败: %v", err)
+		}
+		v, r := big.NewInt(7), big.NewInt(11)
+		if Commit(curve, v, r, nil) != nil {
+			t.Error("H 为 nil 时应该返回 nil")
+		}
+		if Commit(elliptic.P384(), v, r, H) != nil {
+			t.Error("H 不在 curve 上时应该返回 nil")
+		}
+		C := Commit(curve, v, r, H)
+		if Open(nil, v, r, H) || Open(C, nil, r, H) || Open(C, v, r, nil) {
+			t.Error("nil 参数不应该打开承诺")
+		}
+		other := Commit(elliptic.P384(), v, r, ec.ScalarBaseMult(elliptic.P384(), big.NewInt(5)))
+		if Add(C, other) != nil {
+			t.Error("不同曲线上的承诺相加应该返回 nil")
+		}
+		if Add(C, nil) != nil {
+			t.Error("nil 承诺相加应该返回 nil")
+		}
+	})
+}
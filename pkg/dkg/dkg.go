@@ -0,0 +1,137 @@
+package dkg
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"tss-crypto/pkg/ec"
+	"tss-crypto/pkg/mod"
+	"tss-crypto/pkg/vss"
+)
+
+// ---- 错误 ----
+
+// 可用 errors.Is 判断的错误类型
+var (
+	ErrInvalidContribution = errors.New("invalid dealer contribution")
+	ErrNoContributions     = errors.New("no contributions")
+)
+
+// Contribution 是单个 dealer 在 DKG 中的输出：
+// 广播给所有人的 Feldman 承诺，以及按下标分发给各参与方的份额（即 vss.SplitSecret 的返回值）
+type Contribution struct {
+	Commitment *vss.Commitment
+	Shares     vss.Shares
+}
+
+// Result 是 DKG 的聚合结果
+type Result struct {
+	// PublicKey 群公钥 Y = Σ C_0^{(d)} = x·G，其中 x = Σ secret_d
+	PublicKey *ec.Point
+	// Commitment 聚合承诺 Σ C_j^{(d)}，聚合份额可对其调用 Share.Verify
+	Commitment *vss.Commitment
+	// Shares 各参与方的聚合私钥份额 Σ f_d(x_i)，顺序与 indices 一致
+	Shares vss.Shares
+}
+
+// ---- 公开 API ----
+
+// VerifyContribution 检查单个 dealer 的输出：
+// 承诺次数与 threshold 一致，每个下标恰好收到一份份额，且每份都能通过 Feldman 验证。
+// 不通过时返回包装了 ErrInvalidContribution 的错误，调用方据此拒绝该 dealer
+func VerifyContribution(curve elliptic.Curve, threshold int, indices []vss.Index, c *Contribution) error {
+	_, err := sharesByIndex(curve, threshold, indices, c)
+	return err
+}
+
+// Aggregate 聚合所有 dealer 的输出，得到群公钥、聚合承诺和各参与方的聚合份额。
+// 任一 dealer 未通过 VerifyContribution 时返回错误并指出其序号
+func Aggregate(curve elliptic.Curve, threshold int, indices []vss.Index, contributions []*Contribution) (*Result, error) {
+	if len(contributions) == 0 {
+		return nil, ErrNoContributions
+	}
+	indices, err := vss.CheckIndices(curve, indices)
+	if err != nil {
+		return nil, err
+	}
+
+	N := curve.Params().N
+	sums := make([]*big.Int, len(indices))
+	for i := range sums {
+		sums[i] = new(big.Int)
+	}
+	commits := make([]*vss.Commitment, len(contributions))
+
+	for d, c := range contributions {
+		byIndex, err := sharesByIndex(curve, threshold, indices, c)
+		if err != nil {
+			return nil, fmt.Errorf("dealer %d: %w", d, err)
+		}
+		for i, idx := range indices {
+			sums[i] = mod.ModAdd(sums[i], byIndex[idx.String()].Value, N)
+		}
+		commits[d] = c.Commitment
+	}
+
+	commitment, err := vss.MergeCommitments(commits)
+	if err != nil {
+		return nil, err
+	}
+
+	shares := make(vss.Shares, len(indices))
+	for i, idx := range indices {
+		shares[i] = &vss.Share{Index: idx, Value: sums[i], Threshold: threshold}
+	}
+	return &Result{
+		PublicKey:  commitment.Coeffs[0].Copy(),
+		Commitment: commitment,
+		Shares:     shares,
+	}, nil
+}
+
+// ---- 内部实现 ----
+
+// sharesByIndex 校验 dealer 输出并按规范化下标建立 下标 -> 份额 的映射
+func sharesByIndex(curve elliptic.Curve, threshold int, indices []vss.Index, c *Contribution) (map[string]*vss.Share, error) {
+	if curve == nil {
+		return nil, vss.ErrNilCurve
+	}
+	if threshold < 1 {
+		return nil, vss.ErrThresholdTooSmall
+	}
+	indices, err := vss.CheckIndices(curve, indices)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil || c.Commitment == nil {
+		return nil, fmt.Errorf("contribution or commitment is nil: %w", ErrInvalidContribution)
+	}
+	if len(c.Commitment.Coeffs) != threshold {
+		return nil, fmt.Errorf("commitment has %d coefficients, expected %d: %w",
+			len(c.Commitment.Coeffs), threshold, ErrInvalidContribution)
+	}
+
+	N := curve.Params().N
+	byIndex := make(map[string]*vss.Share, len(c.Shares))
+	for i, s := range c.Shares {
+		if s == nil || s.Index == nil {
+			return nil, fmt.Errorf("share %d is nil: %w", i, ErrInvalidContribution)
+		}
+		key := mod.Mod(s.Index, N).String()
+		if byIndex[key] != nil {
+			return nil, fmt.Errorf("share %d: duplicate index: %w", i, ErrInvalidContribution)
+		}
+		if !s.Verify(curve, c.Commitment) {
+			return nil, fmt.Errorf("share %d does not match commitment: %w", i, ErrInvalidContribution)
+		}
+		byIndex[key] = s
+	}
+	for _, idx := range indices {
+		if byIndex[idx.String()] == nil {
+			return nil, fmt.Errorf("missing share for index %v: %w", idx, ErrInvalidContribution)
+		}
+	}
+	return byIndex, nil
+}
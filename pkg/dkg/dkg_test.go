@@ -0,0 +1,136 @@
+package dkg
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+
+	"tss-crypto/pkg/ec"
+	"tss-crypto/pkg/vss"
+)
+
+// runDealers 模拟每个参与方各自作为 dealer 拆分一个随机秘密
+func runDealers(t *testing.T, curve elliptic.Curve, threshold int, indices []vss.Index) ([]*Contribution, *big.Int) {
+	t.Helper()
+	N := curve.Params().N
+	total := new(big.Int)
+	contributions := make([]*Contribution, len(indices))
+	for d := range indices {
+		secret, err := rand.Int(rand.Reader, N)
+		if err != nil {
+			t.Fatalf("生成秘密失败: %v", err)
+		}
+		commit, shares, err := vss.SplitSecret(curve, threshold, secret, indices)
+		if err != nil {
+			t.Fatalf("SplitSecret 失败: %v", err)
+		}
+		contributions[d] = &Contribution{Commitment: commit, Shares: shares}
+		total.Add(total, secret)
+	}
+	return contributions, total.Mod(total, N)
+}
+
+func TestDKG_Integration(t *testing.T) {
+	curve := elliptic.P256()
+	const n, threshold = 5, 3
+	indices, err := vss.SequentialIndices(curve, n)
+	if err != nil {
+		t.Fatalf("SequentialIndices 失败: %v", err)
+	}
+
+	contributions, groupSecret := runDealers(t, curve, threshold, indices)
+	for d, c := range contributions {
+		if err := VerifyContribution(curve, threshold, indices, c); err != nil {
+			t.Fatalf("dealer %d 验证失败: %v", d, err)
+		}
+	}
+
+	result, err := Aggregate(curve, threshold, indices, contributions)
+	if err != nil {
+		t.Fatalf("Aggregate 失败: %v", err)
+	}
+
+	t.Run("群公钥等于 Σ secret · G", func(t *testing.T) {
+		if !result.PublicKey.Equal(ec.ScalarBaseMult(curve, groupSecret)) {
+			t.Error("群公钥不正确")
+		}
+	})
+
+	t.Run("聚合份额通过聚合承诺验证", func(t *testing.T) {
+		for i, s := range result.Shares {
+			if !s.Verify(curve, result.Commitment) {
+				t.Errorf("聚合份额 %d 验证失败", i)
+			}
+		}
+	})
+
+	t.Run("任意 3 个聚合份额重建群秘密", func(t *testing.T) {
+		subsets := [][]int{{0, 1, 2}, {2, 3, 4}, {0, 2, 4}}
+		for _, subset := range subsets {
+			var shares vss.Shares
+			for _, i := range subset {
+				shares = append(shares, result.Shares[i])
+			}
+			got, err := vss.Reconstruct(curve, threshold, shares)
+			if err != nil {
+				t.Fatalf("Reconstruct 失败: %v", err)
+			}
+			if got.Cmp(groupSecret) != 0 {
+				t.Errorf("子集 %v: 重建结果与群秘密不一致", subset)
+			}
+		}
+	})
+}
+
+func TestVerifyContribution(t *testing.T) {
+	curve := elliptic.P256()
+	const threshold = 3
+	indices, _ := vss.SequentialIndices(curve, 5)
+
+	fresh := func() *Contribution {
+		commit, shares, err := vss.SplitSecret(curve, threshold, big.NewInt(7), indices)
+		if err != nil {
+			t.Fatalf("SplitSecret 失败: %v", err)
+		}
+		return &Contribution{Commitment: commit, Shares: shares}
+	}
+
+	t.Run("篡改份额", func(t *testing.T) {
+		c := fresh()
+		c.Shares[2].Value = new(big.Int).Add(c.Shares[2].Value, big.NewInt(1))
+		if err := VerifyContribution(curve, threshold, indices, c); !errors.Is(err, ErrInvalidContribution) {
+			t.Errorf("应该返回 ErrInvalidContribution, 得到 %v", err)
+		}
+	})
+
+	t.Run("缺少份额", func(t *testing.T) {
+		c := fresh()
+		c.Shares = c.Shares[:4]
+		if err := VerifyContribution(curve, threshold, indices, c); !errors.Is(err, ErrInvalidContribution) {
+			t.Errorf("应该返回 ErrInvalidContribution, 得到 %v", err)
+		}
+	})
+
+	t.Run("门限不一致", func(t *testing.T) {
+		c := fresh()
+		if err := VerifyContribution(curve, threshold+1, indices, c); !errors.Is(err, ErrInvalidContribution) {
+			t.Errorf("应该返回 ErrInvalidContribution, 得到 %v", err)
+		}
+	})
+
+	t.Run("Aggregate 拒绝坏 dealer", func(t *testing.T) {
+		good, bad := fresh(), fresh()
+		bad.Shares[0].Value = big.NewInt(1)
+		if _, err := Aggregate(curve, threshold, indices, []*Contribution{good, bad}); !errors.Is(err, ErrInvalidContribution) {
+			t.Errorf("应该返回 ErrInvalidContribution, 得到 %v", err)
+		}
+	})
+
+	t.Run("没有 dealer", func(t *testing.T) {
+		if _, err := Aggregate(curve, threshold, indices, nil); !errors.Is(err, ErrNoContributions) {
+			t.Errorf("应该返回 ErrNoContributions, 得到 %v", err)
+		}
+	})
+}
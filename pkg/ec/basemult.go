@@ -0,0 +1,72 @@
+package ec
+
+import (
+	"crypto/elliptic"
+	"math/big"
+)
+
+// baseMultWindow 是固定基点表的窗口宽度（比特）
+const baseMultWindow = 4
+
+// BaseMultTable 是基点 G 的固定基预计算表，用于反复计算 k·G（如 VSS 承诺）。
+//
+// 表按 4 比特窗口组织：windows[i][j] = j·16^i·G，Mult 只需每个窗口一次点加，
+// 无需倍点。标准库的 NIST 曲线在 ScalarBaseMult 内部已使用预计算表，
+// 因此这些曲线直接委托给 ScalarBaseMult；其余曲线（Ed25519）使用本表。
+type BaseMultTable struct {
+	curve   elliptic.Curve
+	windows [][]*extendedPoint // 仅 Edwards 曲线使用
+}
+
+// NewBaseMultTable 为曲线基点构建预计算表。
+// Ed25519 的表在首次调用时构建并在之后复用，后续调用开销很小
+func NewBaseMultTable(curve elliptic.Curve) *BaseMultTable {
+	t := &BaseMultTable{curve: curve}
+	if c, ok := curve.(*edwardsCurve); ok {
+		c.tableOnce.Do(func() { c.table = c.buildBaseTable() })
+		t.windows = c.table
+	}
+	return t
+}
+
+// Mult 计算 k·G，结果与 ScalarBaseMult(curve, k) 一致；k 按 mod N 解释
+func (t *BaseMultTable) Mult(k *big.Int) *Point {
+	if t.windows == nil {
+		return ScalarBaseMult(t.curve, k)
+	}
+
+	c := t.curve.(*edwardsCurve)
+	kMod := new(big.Int).Mod(k, c.params.N)
+	acc := c.identity()
+	for i, row := range t.windows {
+		var nibble uint
+		for b := 0; b < baseMultWindow; b++ {
+			nibble |= kMod.Bit(i*baseMultWindow+b) << uint(b)
+		}
+		if nibble != 0 {
+			acc = c.add(acc, row[nibble])
+		}
+	}
+	x, y := c.toAffine(acc)
+	return &Point{Curve: t.curve, X: x, Y: y}
+}
+
+// buildBaseTable 计算 windows[i][j] = j·16^i·G，窗口数覆盖 N 的全部比特
+func (c *edwardsCurve) buildBaseTable() [][]*extendedPoint {
+	n := (c.params.N.BitLen() + baseMultWindow - 1) / baseMultWindow
+	size := 1 << baseMultWindow
+
+	windows := make([][]*extendedPoint, n)
+	base := c.fromAffine(c.params.Gx, c.params.Gy)
+	for i := range windows {
+		row := make([]*extendedPoint, size)
+		row[1] = base
+		for j := 2; j < size; j++ {
+			row[j] = c.add(row[j-1], base)
+		}
+		windows[i] = row
+		// 16^(i+1)·G = 15·16^i·G + 16^i·G
+		base = c.add(row[size-1], base)
+	}
+	return windows
+}
@@ -0,0 +1,44 @@
+package ec
+
+import (
+	"crypto/elliptic"
+	"errors"
+)
+
+// MarshalCompressed 按 SEC 1 压缩格式编码点：0x02/0x03 || X（定长大端）。
+// 无穷远点编码为单字节 0x00，解码为 Identity 给出的标准表示
+func (p *Point) MarshalCompressed() ([]byte, error) {
+	if p == nil || p.Curve == nil {
+		return nil, errors.New("ec: point or curve is nil")
+	}
+	if isEdwards(p.Curve) {
+		return nil, errors.New("ec: SEC 1 compression is not defined for Edwards curves")
+	}
+	if p.IsInfinity() {
+		return []byte{0x00}, nil
+	}
+	if !p.IsOnCurve() {
+		return nil, errors.New("ec: point is not on curve")
+	}
+	return elliptic.MarshalCompressed(p.Curve, p.X, p.Y), nil
+}
+
+// UnmarshalCompressed 解码 SEC 1 压缩格式的点。
+// 解出的 Y 总是 [0, P) 内与前缀奇偶性一致的标准代表元，
+// 因此与原始点（同样由标准库运算得到）可直接用 Equal 比较
+func UnmarshalCompressed(curve elliptic.Curve, data []byte) (*Point, error) {
+	if curve == nil {
+		return nil, errors.New("ec: curve is nil")
+	}
+	if isEdwards(curve) {
+		return nil, errors.New("ec: SEC 1 compression is not defined for Edwards curves")
+	}
+	if len(data) == 1 && data[0] == 0x00 {
+		return Identity(curve), nil
+	}
+	x, y := elliptic.UnmarshalCompressed(curve, data)
+	if x == nil {
+		return nil, errors.New("ec: invalid compressed point")
+	}
+	return &Point{Curve: curve, X: x, Y: y}, nil
+}
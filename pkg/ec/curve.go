@@ -0,0 +1,91 @@
+package ec
+
+import (
+	"crypto/elliptic"
+	"encoding/asn1"
+)
+
+// 各 NIST 曲线的 ASN.1 OID（RFC 5480 / SEC 2）
+var (
+	oidP224 = asn1.ObjectIdentifier{1, 3, 132, 0, 33}
+	oidP256 = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+	oidP384 = asn1.ObjectIdentifier{1, 3, 132, 0, 34}
+	oidP521 = asn1.ObjectIdentifier{1, 3, 132, 0, 35}
+
+	// id-Ed25519（RFC 8410）
+	oidEd25519 = asn1.ObjectIdentifier{1, 3, 101, 112}
+)
+
+// CurveOID 返回曲线的标准 ASN.1 OID，不支持的曲线返回 false
+func CurveOID(curve elliptic.Curve) (asn1.ObjectIdentifier, bool) {
+	switch curve {
+	case elliptic.P224():
+		return oidP224, true
+	case elliptic.P256():
+		return oidP256, true
+	case elliptic.P384():
+		return oidP384, true
+	case elliptic.P521():
+		return oidP521, true
+	case Ed25519():
+		return oidEd25519, true
+	}
+	return nil, false
+}
+
+// CurveFromOID 根据 ASN.1 OID 返回对应曲线，未知 OID 返回 false
+func CurveFromOID(oid asn1.ObjectIdentifier) (elliptic.Curve, bool) {
+	switch {
+	case oid.Equal(oidP224):
+		return elliptic.P224(), true
+	case oid.Equal(oidP256):
+		return elliptic.P256(), true
+	case oid.Equal(oidP384):
+		return elliptic.P384(), true
+	case oid.Equal(oidP521):
+		return elliptic.P521(), true
+	case oid.Equal(oidEd25519):
+		return Ed25519(), true
+	}
+	return nil, false
+}
+
+// CurveByName 根据曲线名（Params().Name，如 "P-256"）返回对应曲线
+func CurveByName(name string) (elliptic.Curve, bool) {
+	switch name {
+	case elliptic.P224().Params().Name:
+		return elliptic.P224(), true
+	case elliptic.P256().Params().Name:
+		return elliptic.P256(), true
+	case elliptic.P384().Params().Name:
+		return elliptic.P384(), true
+	case elliptic.P521().Params().Name:
+		return elliptic.P521(), true
+	case Ed25519().Params().Name:
+		return Ed25519(), true
+	}
+	return nil, false
+}
+
+// SameCurve 逐字段比较 Params()（名称、P、N、B、Gx、Gy、BitSize）判断两条曲线是否相同，
+// 分别构造但参数一致的实例视为同一条曲线。Edwards 曲线的运算不走 Params，
+// 所以只与 Edwards 曲线相同
+func SameCurve(a, b elliptic.Curve) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	if a == b {
+		return true
+	}
+	if isEdwards(a) != isEdwards(b) {
+		return false
+	}
+	pa, pb := a.Params(), b.Params()
+	return pa.Name == pb.Name &&
+		pa.BitSize == pb.BitSize &&
+		pa.P.Cmp(pb.P) == 0 &&
+		pa.N.Cmp(pb.N) == 0 &&
+		pa.B.Cmp(pb.B) == 0 &&
+		pa.Gx.Cmp(pb.Gx) == 0 &&
+		pa.Gy.Cmp(pb.Gy) == 0
+}
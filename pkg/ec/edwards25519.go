@@ -0,0 +1,203 @@
+package ec
+
+import (
+	"crypto/elliptic"
+	"crypto/sha512"
+	"errors"
+	"math/big"
+	"sync"
+)
+
+// edwards25519 扭曲 Edwards 曲线 -x^2 + y^2 = 1 + d·x^2·y^2 (mod p)，p = 2^255 - 19。
+// 以 elliptic.Curve 接口暴露，使 vss 等按 Params().N 取模的代码可以直接在
+// Ed25519 群阶 L 上工作。注意与 NIST 曲线的差异：
+//   - 单位元是 (0, 1)，而不是 (0, 0)；
+//   - 取负是 (-x, y)，而不是 (x, -y)；
+//   - Params().B 存放的是 d，Params() 上的 Weierstrass 方法不适用于该曲线。
+type edwardsCurve struct {
+	params *elliptic.CurveParams
+	d      *big.Int
+	d2     *big.Int // 2d
+
+	tableOnce sync.Once
+	table     [][]*extendedPoint // 基点预计算表，见 NewBaseMultTable
+}
+
+var (
+	ed25519Once  sync.Once
+	ed25519Curve *edwardsCurve
+)
+
+// Ed25519 返回 edwards25519 曲线，基点为 RFC 8032 的标准基点，N 为群阶 L
+func Ed25519() elliptic.Curve {
+	ed25519Once.Do(initEd25519)
+	return ed25519Curve
+}
+
+func initEd25519() {
+	p := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+	// d = -121665 / 121666 mod p
+	d := new(big.Int).ModInverse(big.NewInt(121666), p)
+	d.Mul(d, big.NewInt(-121665))
+	d.Mod(d, p)
+	// L = 2^252 + 27742317777372353535851937790883648493
+	l, _ := new(big.Int).SetString("27742317777372353535851937790883648493", 10)
+	l.Add(l, new(big.Int).Lsh(big.NewInt(1), 252))
+	gx, _ := new(big.Int).SetString("15112221349535400772501151409588531511454012693041857206046113283949847762202", 10)
+	gy, _ := new(big.Int).SetString("46316835694926478169428394003475163141307993866256225615783033603165251855960", 10)
+
+	ed25519Curve = &edwardsCurve{
+		params: &elliptic.CurveParams{
+			P:       p,
+			N:       l,
+			B:       d,
+			Gx:      gx,
+			Gy:      gy,
+			BitSize: 255,
+			Name:    "Ed25519",
+		},
+		d:  d,
+		d2: new(big.Int).Lsh(d, 1),
+	}
+}
+
+// isEdwards 判断曲线是否为 Edwards 形式（坐标约定与 Weierstrass 曲线不同）
+func isEdwards(curve elliptic.Curve) bool {
+	_, ok := curve.(*edwardsCurve)
+	return ok
+}
+
+func (c *edwardsCurve) Params() *elliptic.CurveParams {
+	return c.params
+}
+
+// IsOnCurve 检查 -x^2 + y^2 = 1 + d·x^2·y^2 (mod p)，坐标必须已规约到 [0, p)
+func (c *edwardsCurve) IsOnCurve(x, y *big.Int) bool {
+	p := c.params.P
+	if x.Sign() < 0 || x.Cmp(p) >= 0 || y.Sign() < 0 || y.Cmp(p) >= 0 {
+		return false
+	}
+	x2 := new(big.Int).Mul(x, x)
+	y2 := new(big.Int).Mul(y, y)
+	lhs := new(big.Int).Sub(y2, x2)
+	lhs.Mod(lhs, p)
+	rhs := new(big.Int).Mul(x2, y2)
+	rhs.Mod(rhs, p)
+	rhs.Mul(rhs, c.d)
+	rhs.Add(rhs, big.NewInt(1))
+	rhs.Mod(rhs, p)
+	return lhs.Cmp(rhs) == 0
+}
+
+func (c *edwardsCurve) Add(x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int) {
+	return c.toAffine(c.add(c.fromAffine(x1, y1), c.fromAffine(x2, y2)))
+}
+
+func (c *edwardsCurve) Double(x1, y1 *big.Int) (*big.Int, *big.Int) {
+	p := c.fromAffine(x1, y1)
+	return c.toAffine(c.add(p, p))
+}
+
+// ScalarMult 计算 k·(x1, y1)，k 为大端字节，与 elliptic.Curve 约定一致
+func (c *edwardsCurve) ScalarMult(x1, y1 *big.Int, k []byte) (*big.Int, *big.Int) {
+	base := c.fromAffine(x1, y1)
+	acc := c.identity()
+	for _, b := range k {
+		for bit := 7; bit >= 0; bit-- {
+			acc = c.add(acc, acc)
+			if (b>>uint(bit))&1 == 1 {
+				acc = c.add(acc, base)
+			}
+		}
+	}
+	return c.toAffine(acc)
+}
+
+func (c *edwardsCurve) ScalarBaseMult(k []byte) (*big.Int, *big.Int) {
+	return c.ScalarMult(c.params.Gx, c.params.Gy, k)
+}
+
+// ---- 扩展坐标 (X:Y:Z:T)，x = X/Z，y = Y/Z，xy = T/Z ----
+
+type extendedPoint struct {
+	X, Y, Z, T *big.Int
+}
+
+func (c *edwardsCurve) identity() *extendedPoint {
+	return &extendedPoint{X: big.NewInt(0), Y: big.NewInt(1), Z: big.NewInt(1), T: big.NewInt(0)}
+}
+
+func (c *edwardsCurve) fromAffine(x, y *big.Int) *extendedPoint {
+	t := new(big.Int).Mul(x, y)
+	t.Mod(t, c.params.P)
+	return &extendedPoint{X: new(big.Int).Set(x), Y: new(big.Int).Set(y), Z: big.NewInt(1), T: t}
+}
+
+func (c *edwardsCurve) toAffine(e *extendedPoint) (*big.Int, *big.Int) {
+	p := c.params.P
+	zInv := new(big.Int).ModInverse(e.Z, p)
+	x := new(big.Int).Mul(e.X, zInv)
+	x.Mod(x, p)
+	y := new(big.Int).Mul(e.Y, zInv)
+	y.Mod(y, p)
+	return x, y
+}
+
+// add 是 a = -1 时的完备加法公式（RFC 8032 5.1.4），对倍点同样适用
+func (c *edwardsCurve) add(p1, p2 *extendedPoint) *extendedPoint {
+	p := c.params.P
+	mul := func(a, b *big.Int) *big.Int {
+		r := new(big.Int).Mul(a, b)
+		return r.Mod(r, p)
+	}
+	a := mul(new(big.Int).Sub(p1.Y, p1.X), new(big.Int).Sub(p2.Y, p2.X))
+	b := mul(new(big.Int).Add(p1.Y, p1.X), new(big.Int).Add(p2.Y, p2.X))
+	cc := mul(mul(p1.T, c.d2), p2.T)
+	d := mul(new(big.Int).Lsh(p1.Z, 1), p2.Z)
+	e := new(big.Int).Sub(b, a)
+	f := new(big.Int).Sub(d, cc)
+	g := new(big.Int).Add(d, cc)
+	h := new(big.Int).Add(b, a)
+	return &extendedPoint{X: mul(e, f), Y: mul(g, h), T: mul(e, h), Z: mul(f, g)}
+}
+
+// ---- RFC 8032 编码 ----
+
+// Ed25519ScalarFromSeed 按 RFC 8032 从 32 字节种子导出私钥标量 a：
+// 取 SHA-512(seed) 的前 32 字节，clamp 后按小端解释。a·B 即 crypto/ed25519 的公钥。
+// 返回值未对 L 取模，与 clamp 后的整数完全一致
+func Ed25519ScalarFromSeed(seed []byte) (*big.Int, error) {
+	if len(seed) != 32 {
+		return nil, errors.New("ec: Ed25519 seed must be 32 bytes")
+	}
+	h := sha512.Sum512(seed)
+	a := h[:32]
+	a[0] &= 248
+	a[31] &= 127
+	a[31] |= 64
+	// 小端转大端
+	be := make([]byte, 32)
+	for i := range a {
+		be[31-i] = a[i]
+	}
+	return new(big.Int).SetBytes(be), nil
+}
+
+// MarshalEd25519 按 RFC 8032 编码 Ed25519 点：y 的 32 字节小端表示，最高位存放 x 的奇偶性。
+// 对 a·B 的编码即为 crypto/ed25519 的公钥格式
+func (p *Point) MarshalEd25519() ([]byte, error) {
+	if p == nil || p.Curve == nil || !isEdwards(p.Curve) {
+		return nil, errors.New("ec: point is not on Ed25519")
+	}
+	if p.IsInfinity() || !p.IsOnCurve() {
+		return nil, errors.New("ec: invalid Ed25519 point")
+	}
+	out := make([]byte, 32)
+	p.Y.FillBytes(out)
+	// 大端转小端
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	out[31] |= byte(p.X.Bit(0)) << 7
+	return out, nil
+}
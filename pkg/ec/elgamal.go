@@ -0,0 +1,45 @@
+package ec
+
+import (
+	"crypto/elliptic"
+	"math/big"
+)
+
+// ElGamalCommit 计算指数 ElGamal 承诺 (a, b) = (r·G, m·G + r·H)。
+// 若 H = x·G，持有 x 者可由 b - x·a = m·G 恢复 m·G（“解密”到指数上）；
+// 两个承诺逐分量相加即承诺 m1+m2、随机数 r1+r2。
+// H 为 nil、属于其他曲线或坐标不满足曲线方程时返回 (nil, nil)
+func ElGamalCommit(curve elliptic.Curve, m, r *big.Int, H *Point) (a, b *Point) {
+	if curve == nil || m == nil || r == nil || H == nil || H.Curve == nil {
+		return nil, nil
+	}
+	if !SameCurve(curve, H.Curve) || !validElGamalPoint(H) {
+		return nil, nil
+	}
+	a = ScalarBaseMult(curve, r)
+	b = ScalarBaseMult(curve, m).Add(H.ScalarMult(r))
+	return a, b
+}
+
+// ElGamalReRandomize 用新的随机数 s 重新随机化承诺：(a + s·G, b + s·H)。
+// 结果承诺同一个 m，随机数变为 r+s，且与原承诺不可关联。
+// 参数为 nil、曲线不一致或 a、b、H 不在曲线上时返回 (nil, nil)
+func ElGamalReRandomize(a, b *Point, s *big.Int, H *Point) (*Point, *Point) {
+	if a == nil || b == nil || s == nil || H == nil || a.Curve == nil {
+		return nil, nil
+	}
+	if !SameCurve(a.Curve, b.Curve) || H.Curve == nil || !SameCurve(a.Curve, H.Curve) {
+		return nil, nil
+	}
+	if !validElGamalPoint(a) || !validElGamalPoint(b) || !validElGamalPoint(H) {
+		return nil, nil
+	}
+	a2 := a.Add(ScalarBaseMult(a.Curve, s))
+	b2 := b.Add(H.ScalarMult(s))
+	return a2, b2
+}
+
+// validElGamalPoint 判断点是无穷远点或在曲线上；标准库的点运算遇到曲线外的点会 panic
+func validElGamalPoint(p *Point) bool {
+	return p.IsInfinity() || p.IsOnCurve()
+}
@@ -0,0 +1,98 @@
+package ec
+
+import (
+	"crypto/elliptic"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+// hashToPointMaxAttempts 是 try-and-increment 的计数器上限。
+// 每次尝试成功的概率约 1/2，256 次全部失败的概率约 2^-256
+const hashToPointMaxAttempts = 256
+
+// hashToPointDomain 是 HashToPoint 的域分隔标签
+const hashToPointDomain = "tss-crypto/ec/hash-to-point"
+
+var errHashToPoint = errors.New("ec: hash to point failed: no valid point within attempt bound")
+
+// HashToPoint 用 try-and-increment 将 label 确定性地映射为曲线上的点：
+// 对 counter = 0, 1, ...，令 x = H(domain || counter || label) mod p，
+// 若 x^3 - 3x + b 是模 p 的二次剩余，取偶数 y 作为结果。
+// 循环次数有上界，找不到有效点（或只得到无穷远点）时返回错误而不是无限循环。
+// 适用于 a = -3 的短 Weierstrass 曲线（即 elliptic.CurveParams 描述的曲线）。
+func HashToPoint(curve elliptic.Curve, label []byte) (*Point, error) {
+	if curve == nil {
+		return nil, errors.New("ec: curve is nil")
+	}
+	if isEdwards(curve) {
+		return nil, errors.New("ec: HashToPoint supports short Weierstrass curves only")
+	}
+	params := curve.Params()
+	P := params.P
+	byteLen := (P.BitLen() + 7) / 8
+
+	three := big.NewInt(3)
+	for counter := 0; counter < hashToPointMaxAttempts; counter++ {
+		x := new(big.Int).SetBytes(expandHash(label, byte(counter), byteLen+16))
+		x.Mod(x, P)
+
+		// rhs = x^3 - 3x + b mod p
+		rhs := new(big.Int).Exp(x, three, P)
+		rhs.Sub(rhs, new(big.Int).Mul(x, three))
+		rhs.Add(rhs, params.B)
+		rhs.Mod(rhs, P)
+
+		y := new(big.Int).ModSqrt(rhs, P)
+		if y == nil {
+			continue
+		}
+		// 规范化：取偶数 y，保证确定性
+		if y.Bit(0) == 1 {
+			y.Sub(P, y)
+		}
+
+		pt := &Point{Curve: curve, X: x, Y: y}
+		if pt.IsInfinity() || !pt.IsOnCurve() {
+			continue
+		}
+		return pt, nil
+	}
+	return nil, errHashToPoint
+}
+
+// deriveHDomain 是 DeriveH 的域分隔标签
+const deriveHDomain = "tss-crypto/ec/pedersen-H"
+
+// DeriveH 为 Pedersen 承诺确定性地派生第二个生成元 H：
+// 把域标签、曲线名和基点 G 的未压缩编码交给 HashToPoint，
+// 得到的 H 由哈希决定，没有人知道 log_G(H)（nothing-up-my-sleeve）。
+// 同一条曲线总是得到同一个 H；与 HashToPoint 一样只支持短 Weierstrass 曲线
+func DeriveH(curve elliptic.Curve) (*Point, error) {
+	if curve == nil {
+		return nil, errors.New("ec: curve is nil")
+	}
+	params := curve.Params()
+	byteLen := (params.P.BitLen() + 7) / 8
+
+	label := make([]byte, 0, len(deriveHDomain)+len(params.Name)+1+2*byteLen)
+	label = append(label, deriveHDomain...)
+	label = append(label, params.Name...)
+	label = append(label, 0x04)
+	label = append(label, params.Gx.FillBytes(make([]byte, byteLen))...)
+	label = append(label, params.Gy.FillBytes(make([]byte, byteLen))...)
+	return HashToPoint(curve, label)
+}
+
+// expandHash 以计数器模式扩展 SHA-256 输出到 n 字节（多取的字节用于降低取模偏差）
+func expandHash(label []byte, counter byte, n int) []byte {
+	out := make([]byte, 0, n+sha256.Size)
+	for block := byte(0); len(out) < n; block++ {
+		h := sha256.New()
+		h.Write([]byte(hashToPointDomain))
+		h.Write([]byte{counter, block})
+		h.Write(label)
+		out = h.Sum(out)
+	}
+	return out[:n]
+}
@@ -2,9 +2,14 @@ package ec
 
 import (
 	"crypto/elliptic"
+	"errors"
+	"fmt"
 	"math/big"
 )
 
+// ErrCurveMismatch 表示参与运算的点不在同一条曲线上
+var ErrCurveMismatch = errors.New("ec: points are on different curves")
+
 // Point 表示椭圆曲线上的点
 type Point struct {
 	Curve elliptic.Curve
@@ -21,10 +26,22 @@ func NewPoint(curve elliptic.Curve, x, y *big.Int) *Point {
 	}
 }
 
+// Identity 返回曲线的单位元（无穷远点）的标准表示：
+// Weierstrass 曲线上为标准库使用的 (0,0)，Edwards 曲线（Ed25519）上为 (0,1)。
+// 包内所有运算在结果为单位元时都返回这一表示
+func Identity(curve elliptic.Curve) *Point {
+	if isEdwards(curve) {
+		return &Point{Curve: curve, X: big.NewInt(0), Y: big.NewInt(1)}
+	}
+	return &Point{Curve: curve, X: big.NewInt(0), Y: big.NewInt(0)}
+}
+
 // ScalarBaseMult 计算 k * G，其中 G 是基点，k 是标量
+// 标量按 mod N（曲线阶）解释，负数同样取模
 // 返回新点，不修改原点
 func ScalarBaseMult(curve elliptic.Curve, k *big.Int) *Point {
-	x, y := curve.ScalarBaseMult(k.Bytes())
+	kMod := new(big.Int).Mod(k, curve.Params().N)
+	x, y := curve.ScalarBaseMult(kMod.Bytes())
 	return &Point{
 		Curve: curve,
 		X:     x,
@@ -33,12 +50,20 @@ func ScalarBaseMult(curve elliptic.Curve, k *big.Int) *Point {
 }
 
 // ScalarMult 计算 k * P，其中 P 是当前点，k 是标量
+// 标量按 mod N（曲线阶）解释；负标量通过对点取负处理：(-k) * P = k * (-P)
 // 返回新点，不修改原点
 func (p *Point) ScalarMult(k *big.Int) *Point {
 	if p == nil || p.Curve == nil {
 		return nil
 	}
-	x, y := p.Curve.ScalarMult(p.X, p.Y, k.Bytes())
+	if p.IsInfinity() {
+		return Identity(p.Curve)
+	}
+	if k.Sign() < 0 {
+		return p.Neg().ScalarMult(new(big.Int).Neg(k))
+	}
+	kMod := new(big.Int).Mod(k, p.Curve.Params().N)
+	x, y := p.Curve.ScalarMult(p.X, p.Y, kMod.Bytes())
 	return &Point{
 		Curve: p.Curve,
 		X:     x,
@@ -46,15 +71,25 @@ func (p *Point) ScalarMult(k *big.Int) *Point {
 	}
 }
 
-// Add 计算 P + Q，返回新点，不修改原点
+// Add 计算 P + Q，返回新点，不修改原点；任一操作数为无穷远点时返回另一个点的副本
 func (p *Point) Add(q *Point) *Point {
 	if p == nil || q == nil || p.Curve == nil || q.Curve == nil {
 		return nil
 	}
-	// 检查是否在同一曲线上
-	if p.Curve != q.Curve {
+	// 检查是否在同一曲线上（按参数比较）
+	if !SameCurve(p.Curve, q.Curve) {
 		return nil
 	}
+	if p.IsInfinity() {
+		return q.Copy()
+	}
+	if q.IsInfinity() {
+		return p.Copy()
+	}
+	// P == Q 时显式走倍点，不依赖各曲线 Add 实现对相同输入的处理
+	if p.Equal(q) {
+		return p.Double()
+	}
 	x, y := p.Curve.Add(p.X, p.Y, q.X, q.Y)
 	return &Point{
 		Curve: p.Curve,
@@ -63,11 +98,99 @@ func (p *Point) Add(q *Point) *Point {
 	}
 }
 
-// Equal 检查两个点是否相等
+// Double 计算 2P，返回新点，不修改原点；无穷远点的倍点为 Identity
+func (p *Point) Double() *Point {
+	if p == nil || p.Curve == nil {
+		return nil
+	}
+	if p.IsInfinity() {
+		return Identity(p.Curve)
+	}
+	x, y := p.Curve.Double(p.X, p.Y)
+	return &Point{
+		Curve: p.Curve,
+		X:     x,
+		Y:     y,
+	}
+}
+
+// Sub 计算 P - Q，返回新点，不修改原点；不在同一曲线上时返回 nil
+func (p *Point) Sub(q *Point) *Point {
+	if q == nil {
+		return nil
+	}
+	return p.Add(q.Neg())
+}
+
+// Sum 计算 P_1 + P_2 + ... + P_k。points 为空、含 nil 或不在同一曲线上时返回错误。
+// 无穷远点不参与累加；全部为无穷远点时返回 Identity
+func Sum(points ...*Point) (*Point, error) {
+	if len(points) == 0 {
+		return nil, errors.New("ec: no points to sum")
+	}
+	if points[0] == nil || points[0].Curve == nil {
+		return nil, errors.New("ec: point 0 is nil")
+	}
+	curve := points[0].Curve
+	var result *Point
+	for i, pt := range points {
+		if pt == nil || pt.Curve == nil {
+			return nil, fmt.Errorf("ec: point %d is nil", i)
+		}
+		if !SameCurve(curve, pt.Curve) {
+			return nil, fmt.Errorf("ec: point %d: %w", i, ErrCurveMismatch)
+		}
+		if pt.IsInfinity() {
+			continue
+		}
+		if result == nil {
+			// 返回副本，不让调用方拿到输入的别名
+			result = pt.Copy()
+			continue
+		}
+		result = result.Add(pt)
+	}
+	if result == nil {
+		return Identity(curve), nil
+	}
+	return result, nil
+}
+
+// Neg 计算 -P = (x, -y mod p)，返回新点，不修改原点
+// Edwards 曲线（Ed25519）上取负为 (-x mod p, y)；无穷远点取负为 Identity
+func (p *Point) Neg() *Point {
+	if p == nil || p.Curve == nil {
+		return nil
+	}
+	if p.IsInfinity() {
+		return Identity(p.Curve)
+	}
+	if isEdwards(p.Curve) {
+		x := new(big.Int).Neg(p.X)
+		x.Mod(x, p.Curve.Params().P)
+		return &Point{
+			Curve: p.Curve,
+			X:     x,
+			Y:     new(big.Int).Set(p.Y),
+		}
+	}
+	y := new(big.Int).Neg(p.Y)
+	y.Mod(y, p.Curve.Params().P)
+	return &Point{
+		Curve: p.Curve,
+		X:     new(big.Int).Set(p.X),
+		Y:     y,
+	}
+}
+
+// Equal 检查两个点是否相等，无穷远点（任一表示）只与无穷远点相等
 func (p *Point) Equal(q *Point) bool {
 	if p == nil || q == nil {
 		return p == q
 	}
+	if p.IsInfinity() || q.IsInfinity() {
+		return p.IsInfinity() && q.IsInfinity()
+	}
 	return p.X.Cmp(q.X) == 0 && p.Y.Cmp(q.Y) == 0
 }
 
@@ -79,22 +202,56 @@ func (p *Point) IsOnCurve() bool {
 	return p.Curve.IsOnCurve(p.X, p.Y)
 }
 
-// IsInfinity 检查点是否为无穷远点（通常 X 和 Y 都为 nil）
+// IsInfinity 检查点是否为无穷远点：标准表示见 Identity；
+// 为兼容旧数据，X、Y 都为 nil 的点同样视为无穷远点
 func (p *Point) IsInfinity() bool {
 	if p == nil {
 		return true
 	}
-	return p.X == nil && p.Y == nil
+	if p.X == nil && p.Y == nil {
+		return true
+	}
+	if p.X == nil || p.Y == nil {
+		return false
+	}
+	if p.Curve != nil && isEdwards(p.Curve) {
+		return p.X.Sign() == 0 && p.Y.Cmp(big.NewInt(1)) == 0
+	}
+	return p.X.Sign() == 0 && p.Y.Sign() == 0
 }
 
-// Copy 返回点的副本
+// Order 返回点所在曲线的群阶 N（标量按 mod N 解释）；p 或 Curve 为 nil 时返回 nil。
+// 返回值与 Curve.Params().N 是同一个对象，调用方不应修改
+func (p *Point) Order() *big.Int {
+	if p == nil || p.Curve == nil {
+		return nil
+	}
+	return p.Curve.Params().N
+}
+
+// Field 返回点所在曲线的基域素数 P（坐标按 mod P 解释）；p 或 Curve 为 nil 时返回 nil。
+// 返回值与 Curve.Params().P 是同一个对象，调用方不应修改
+func (p *Point) Field() *big.Int {
+	if p == nil || p.Curve == nil {
+		return nil
+	}
+	return p.Curve.Params().P
+}
+
+// Copy 返回点的副本；无穷远点的副本为 Identity 给出的标准表示
 func (p *Point) Copy() *Point {
 	if p == nil {
 		return nil
 	}
-	return &Point{
-		Curve: p.Curve,
-		X:     new(big.Int).Set(p.X),
-		Y:     new(big.Int).Set(p.Y),
+	if p.IsInfinity() && p.Curve != nil {
+		return Identity(p.Curve)
+	}
+	c := &Point{Curve: p.Curve}
+	if p.X != nil {
+		c.X = new(big.Int).Set(p.X)
+	}
+	if p.Y != nil {
+		c.Y = new(big.Int).Set(p.Y)
 	}
+	return c
 }
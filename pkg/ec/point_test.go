@@ -0,0 +1,1002 @@
+package ec
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"tss-crypto/pkg/mod"
+)
+
+var testCurves = []elliptic.Curve{
+	elliptic.P224(),
+	elliptic.P256(),
+	elliptic.P384(),
+	elliptic.P521(),
+}
+
+// ================= 标量乘法测试 =================
+
+func TestPoint_ScalarMult(t *testing.T) {
+	for _, curve := range testCurves {
+		t.Run(curve.Params().Name, func(t *testing.T) {
+			N := curve.Params().N
+			P := ScalarBaseMult(curve, big.NewInt(987654321))
+			k := big.NewInt(123456)
+
+			t.Run("k + N 与 k 结果相同", func(t *testing.T) {
+				kPlusN := new(big.Int).Add(k, N)
+				if !P.ScalarMult(kPlusN).Equal(P.ScalarMult(k)) {
+					t.Error("ScalarMult(k + N) 应该等于 ScalarMult(k)")
+				}
+				if !ScalarBaseMult(curve, kPlusN).Equal(ScalarBaseMult(curve, k)) {
+					t.Error("ScalarBaseMult(k + N) 应该等于 ScalarBaseMult(k)")
+				}
+			})
+
+			t.Run("负标量", func(t *testing.T) {
+				negK := new(big.Int).Neg(k)
+				got := P.ScalarMult(negK)
+				if !got.Equal(P.Neg().ScalarMult(k)) {
+					t.Error("ScalarMult(-k) 应该等于 Neg().ScalarMult(k)")
+				}
+				if !got.Equal(P.ScalarMult(new(big.Int).Sub(N, k))) {
+					t.Error("ScalarMult(-k) 应该等于 ScalarMult(N - k)")
+				}
+				if !ScalarBaseMult(curve, negK).Equal(ScalarBaseMult(curve, k).Neg()) {
+					t.Error("ScalarBaseMult(-k) 结果不一致")
+				}
+			})
+
+			t.Run("Neg 在曲线上且 P + (-P) 的 x 坐标一致", func(t *testing.T) {
+				neg := P.Neg()
+				if !neg.IsOnCurve() {
+					t.Error("-P 应该在曲线上")
+				}
+				if neg.X.Cmp(P.X) != 0 || neg.Y.Cmp(P.Y) == 0 {
+					t.Error("-P 应该只翻转 y 坐标")
+				}
+				if !neg.Neg().Equal(P) {
+					t.Error("-(-P) 应该等于 P")
+				}
+			})
+		})
+	}
+}
+
+func TestScalarMultNAF(t *testing.T) {
+	for _, curve := range append(testCurves, Ed25519()) {
+		t.Run(curve.Params().Name, func(t *testing.T) {
+			N := curve.Params().N
+			base, err := RandScalar(curve, rand.Reader)
+			if err != nil {
+				t.Fatalf("生成标量失败: %v", err)
+			}
+			P := ScalarBaseMult(curve, base)
+
+			check := func(k *big.Int) {
+				t.Helper()
+				want := P.ScalarMult(k)
+				got := ScalarMultNAF(P, k)
+				if !got.Equal(want) {
+					t.Fatalf("k = %v: ScalarMultNAF 与 ScalarMult 结果不一致", k)
+				}
+			}
+
+			// 边界标量
+			edges := []*big.Int{
+				big.NewInt(0), big.NewInt(1), big.NewInt(2), big.NewInt(3),
+				big.NewInt(15), big.NewInt(16), big.NewInt(31),
+				big.NewInt(-1), big.NewInt(-2), big.NewInt(-31),
+				new(big.Int).Sub(N, big.NewInt(1)),
+				new(big.Int).Set(N),
+				new(big.Int).Add(N, big.NewInt(1)),
+				new(big.Int).Neg(N),
+				new(big.Int).Lsh(big.NewInt(1), uint(N.BitLen()-1)),
+			}
+			for _, k := range edges {
+				check(k)
+			}
+
+			// 随机标量，包括负数与超过 N 的值
+			bound := new(big.Int).Lsh(N, 2)
+			for i := 0; i < 32; i++ {
+				k, _ := rand.Int(rand.Reader, bound)
+				check(k)
+				check(new(big.Int).Neg(k))
+			}
+		})
+	}
+
+	t.Run("wNAF 表示", func(t *testing.T) {
+		bound := new(big.Int).Lsh(big.NewInt(1), 300)
+		for i := 0; i < 100; i++ {
+			k, _ := rand.Int(rand.Reader, bound)
+			k.Add(k, big.NewInt(1))
+			digits := wnaf(k, nafWidth)
+			// 重新求值应该得到 k，非零数字为奇数且 |d| < 2^(w-1)，相邻非零数字至少隔 w-1 个 0
+			sum := new(big.Int)
+			last := -nafWidth
+			for j := len(digits) - 1; j >= 0; j-- {
+				sum.Lsh(sum, 1)
+				d := digits[j]
+				sum.Add(sum, big.NewInt(int64(d)))
+				if d == 0 {
+					continue
+				}
+				if d%2 == 0 || d >= 1<<(nafWidth-1) || d <= -(1<<(nafWidth-1)) {
+					t.Fatalf("非法数字 %d", d)
+				}
+				if last-j < nafWidth && last != -nafWidth {
+					t.Fatalf("非零数字间隔不足: 位置 %d 与 %d", last, j)
+				}
+				last = j
+			}
+			if sum.Cmp(k) != 0 {
+				t.Fatalf("wNAF 求值 %v 不等于 %v", sum, k)
+			}
+		}
+	})
+
+	t.Run("nil 参数", func(t *testing.T) {
+		if ScalarMultNAF(nil, big.NewInt(1)) != nil {
+			t.Error("nil 点应该返回 nil")
+		}
+		if ScalarMultNAF(ScalarBaseMult(elliptic.P256(), big.NewInt(1)), nil) != nil {
+			t.Error("nil 标量应该返回 nil")
+		}
+	})
+
+	t.Run("无穷远点", func(t *testing.T) {
+		for _, curve := range append(testCurves, Ed25519()) {
+			for _, inf := range []*Point{{Curve: curve}, Identity(curve)} {
+				got := ScalarMultNAF(inf, big.NewInt(7))
+				if got == nil || !got.Equal(Identity(curve)) || got.X == nil {
+					t.Errorf("%s: k·O 应该返回 Identity", curve.Params().Name)
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkScalarMultNAF(b *testing.B) {
+	for _, curve := range []elliptic.Curve{elliptic.P256(), Ed25519()} {
+		P := ScalarBaseMult(curve, big.NewInt(12345))
+		k, _ := RandScalar(curve, rand.Reader)
+		b.Run(curve.Params().Name+"/NAF", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ScalarMultNAF(P, k)
+			}
+		})
+		b.Run(curve.Params().Name+"/ScalarMult", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				P.ScalarMult(k)
+			}
+		})
+	}
+}
+
+func TestPoint_Double(t *testing.T) {
+	for _, curve := range append(testCurves, Ed25519()) {
+		t.Run(curve.Params().Name, func(t *testing.T) {
+			for i := 0; i < 10; i++ {
+				k, _ := rand.Int(rand.Reader, curve.Params().N)
+				P := ScalarBaseMult(curve, k)
+				if !P.Add(P).Equal(P.Double()) {
+					t.Fatal("P.Add(P) 应该等于 P.Double()")
+				}
+				if !P.Double().Equal(P.ScalarMult(big.NewInt(2))) {
+					t.Fatal("P.Double() 应该等于 2·P")
+				}
+				// 不同指针、相同坐标的点同样走倍点
+				if !P.Add(P.Copy()).Equal(P.Double()) {
+					t.Fatal("P.Add(P 的副本) 应该等于 P.Double()")
+				}
+			}
+
+			inf := &Point{Curve: curve}
+			for _, got := range []*Point{inf.Double(), inf.Neg(), Identity(curve).Neg()} {
+				if !got.IsInfinity() || !got.Equal(Identity(curve)) || got.X == nil {
+					t.Error("无穷远点的倍点与负元应该是标准表示的无穷远点")
+				}
+			}
+		})
+	}
+}
+
+func TestIdentity(t *testing.T) {
+	curves := append([]elliptic.Curve{Ed25519()}, testCurves...)
+	for _, curve := range curves {
+		t.Run(curve.Params().Name, func(t *testing.T) {
+			id := Identity(curve)
+			zero := ScalarBaseMult(curve, big.NewInt(0))
+			legacy := &Point{Curve: curve}
+			P := ScalarBaseMult(curve, big.NewInt(9))
+
+			t.Run("各种表示相互一致", func(t *testing.T) {
+				for _, inf := range []*Point{id, zero, legacy} {
+					if !inf.IsInfinity() {
+						t.Errorf("(%v, %v) 应该被识别为无穷远点", inf.X, inf.Y)
+					}
+				}
+				if !id.Equal(zero) || !zero.Equal(legacy) {
+					t.Error("0·G、Identity 与旧的 nil 坐标表示应该相等")
+				}
+				if P.IsInfinity() || P.Equal(id) {
+					t.Error("9·G 不应该是无穷远点")
+				}
+				if !P.Add(P.Neg()).Equal(id) {
+					t.Error("P + (-P) 应该等于 Identity")
+				}
+			})
+
+			t.Run("与单位元相加", func(t *testing.T) {
+				for _, inf := range []*Point{id, zero, legacy} {
+					if !P.Add(inf).Equal(P) || !inf.Add(P).Equal(P) {
+						t.Error("P + O 与 O + P 应该等于 P")
+					}
+					sum := inf.Add(inf)
+					if sum == nil || !sum.IsInfinity() || sum.X == nil {
+						t.Error("O + O 应该返回标准表示的无穷远点")
+					}
+				}
+			})
+
+			t.Run("副本与标量乘法", func(t *testing.T) {
+				c := legacy.Copy()
+				if c.X == nil || c.Y == nil || !c.Equal(id) {
+					t.Error("无穷远点的副本应该是标准表示")
+				}
+				if !legacy.ScalarMult(big.NewInt(5)).Equal(id) {
+					t.Error("k·O 应该是无穷远点")
+				}
+			})
+
+			if isEdwards(curve) {
+				return
+			}
+			t.Run("压缩编码往返", func(t *testing.T) {
+				for _, inf := range []*Point{id, zero, legacy} {
+					data, err := inf.MarshalCompressed()
+					if err != nil {
+						t.Fatalf("MarshalCompressed 失败: %v", err)
+					}
+					decoded, err := UnmarshalCompressed(curve, data)
+					if err != nil {
+						t.Fatalf("UnmarshalCompressed 失败: %v", err)
+					}
+					if !decoded.Equal(zero) || decoded.X == nil {
+						t.Error("解码结果应该等于 0·G 的标准表示")
+					}
+					if !decoded.Add(P).Equal(P) {
+						t.Error("解码出的无穷远点与 P 相加应该得到 P")
+					}
+				}
+			})
+		})
+	}
+}
+
+// ================= 文本编码测试 =================
+
+func TestPoint_OrderField(t *testing.T) {
+	for _, curve := range append(testCurves, Ed25519()) {
+		t.Run(curve.Params().Name, func(t *testing.T) {
+			p := ScalarBaseMult(curve, big.NewInt(7))
+			if p.Order().Cmp(curve.Params().N) != 0 {
+				t.Error("Order 应该等于 Params().N")
+			}
+			if p.Field().Cmp(curve.Params().P) != 0 {
+				t.Error("Field 应该等于 Params().P")
+			}
+			// 无穷远点同样能取到曲线参数
+			inf := &Point{Curve: curve}
+			if inf.Order() == nil || inf.Field() == nil {
+				t.Error("无穷远点也应该返回曲线参数")
+			}
+		})
+	}
+
+	t.Run("nil 安全", func(t *testing.T) {
+		var nilPoint *Point
+		if nilPoint.Order() != nil || nilPoint.Field() != nil {
+			t.Error("nil 点应该返回 nil")
+		}
+		noCurve := &Point{X: big.NewInt(1), Y: big.NewInt(2)}
+		if noCurve.Order() != nil || noCurve.Field() != nil {
+			t.Error("Curve 为 nil 时应该返回 nil")
+		}
+	})
+}
+
+func TestSameCurve(t *testing.T) {
+	p256 := elliptic.P256()
+	params := *p256.Params()
+	rebuilt := &params // 参数相同但实例不同的 P-256
+
+	t.Run("曲线比较", func(t *testing.T) {
+		if !SameCurve(p256, rebuilt) {
+			t.Error("参数相同的 P-256 应该视为同一曲线")
+		}
+		if SameCurve(p256, elliptic.P384()) {
+			t.Error("P-256 与 P-384 不应该视为同一曲线")
+		}
+		edParams := *Ed25519().Params()
+		if SameCurve(Ed25519(), &edParams) {
+			t.Error("Ed25519 与按其参数构造的 Weierstrass 曲线不应该视为同一曲线")
+		}
+		if SameCurve(nil, p256) || SameCurve(p256, nil) {
+			t.Error("nil 曲线不应该与任何曲线相同")
+		}
+	})
+
+	a := ScalarBaseMult(p256, big.NewInt(3))
+	b := ScalarBaseMult(rebuilt, big.NewInt(5))
+	c := ScalarBaseMult(elliptic.P384(), big.NewInt(5))
+
+	t.Run("Add/Sub", func(t *testing.T) {
+		sum := a.Add(b)
+		if sum == nil || !sum.Equal(ScalarBaseMult(p256, big.NewInt(8))) {
+			t.Error("3G + 5G 应该等于 8G")
+		}
+		diff := b.Sub(a)
+		if diff == nil || !diff.Equal(ScalarBaseMult(p256, big.NewInt(2))) {
+			t.Error("5G - 3G 应该等于 2G")
+		}
+		if a.Add(c) != nil || a.Sub(c) != nil {
+			t.Error("不同曲线上的点相加减应该返回 nil")
+		}
+	})
+
+	t.Run("Sum", func(t *testing.T) {
+		sum, err := Sum(a, b, a)
+		if err != nil {
+			t.Fatalf("Sum 失败: %v", err)
+		}
+		if !sum.Equal(ScalarBaseMult(p256, big.NewInt(11))) {
+			t.Error("3G + 5G + 3G 应该等于 11G")
+		}
+		if _, err := Sum(a, c); !errors.Is(err, ErrCurveMismatch) {
+			t.Errorf("应该返回 ErrCurveMismatch, 得到 %v", err)
+		}
+		if _, err := Sum(); err == nil {
+			t.Error("应该返回错误当没有点")
+		}
+		if _, err := Sum(a, nil); err == nil {
+			t.Error("应该返回错误当点为 nil")
+		}
+		single, err := Sum(a)
+		if err != nil || single == a || !single.Equal(a) {
+			t.Error("单个点求和应该返回等值的副本")
+		}
+	})
+
+	t.Run("Sum 跳过无穷远点", func(t *testing.T) {
+		legacy := &Point{Curve: p256}
+		zero := ScalarBaseMult(p256, big.NewInt(0))
+		sum, err := Sum(legacy, a, zero, b, Identity(p256))
+		if err != nil {
+			t.Fatalf("Sum 失败: %v", err)
+		}
+		if !sum.Equal(ScalarBaseMult(p256, big.NewInt(8))) {
+			t.Error("O + 3G + O + 5G + O 应该等于 8G")
+		}
+		only, err := Sum(legacy, zero)
+		if err != nil {
+			t.Fatalf("Sum 失败: %v", err)
+		}
+		if !only.IsInfinity() || only.X == nil {
+			t.Error("全部为无穷远点时应该返回标准表示的无穷远点")
+		}
+		if _, err := Sum(legacy, c); !errors.Is(err, ErrCurveMismatch) {
+			t.Errorf("无穷远点同样要检查曲线, 得到 %v", err)
+		}
+	})
+}
+
+func TestPoint_MarshalText(t *testing.T) {
+	for _, curve := range testCurves {
+		t.Run(curve.Params().Name, func(t *testing.T) {
+			p := ScalarBaseMult(curve, big.NewInt(123456789))
+
+			text, err := p.MarshalText()
+			if err != nil {
+				t.Fatalf("MarshalText 失败: %v", err)
+			}
+			if !strings.HasPrefix(string(text), curve.Params().Name+":") {
+				t.Errorf("编码应该以曲线名开头, 得到 %s", text)
+			}
+
+			var decoded Point
+			if err := decoded.UnmarshalText(text); err != nil {
+				t.Fatalf("UnmarshalText 失败: %v", err)
+			}
+			if decoded.Curve != curve {
+				t.Error("解码后的曲线不一致")
+			}
+			if !decoded.Equal(p) {
+				t.Error("解码后的点不一致")
+			}
+		})
+	}
+
+	t.Run("无穷远点", func(t *testing.T) {
+		inf := &Point{Curve: elliptic.P256()}
+		text, err := inf.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText 失败: %v", err)
+		}
+		if string(text) != "inf" {
+			t.Errorf("无穷远点应该编码为 inf, 得到 %s", text)
+		}
+
+		decoded := ScalarBaseMult(elliptic.P256(), big.NewInt(5))
+		if err := decoded.UnmarshalText(text); err != nil {
+			t.Fatalf("UnmarshalText 失败: %v", err)
+		}
+		if !decoded.IsInfinity() || !decoded.Equal(Identity(elliptic.P256())) {
+			t.Error("解码结果应该是无穷远点")
+		}
+		if decoded.X == nil || decoded.X.Sign() != 0 || decoded.Y.Sign() != 0 {
+			t.Errorf("解码结果应该是标准表示 (0,0), 得到 (%v, %v)", decoded.X, decoded.Y)
+		}
+
+		edDecoded := ScalarBaseMult(Ed25519(), big.NewInt(5))
+		if err := edDecoded.UnmarshalText(text); err != nil {
+			t.Fatalf("UnmarshalText 失败: %v", err)
+		}
+		if edDecoded.X == nil || edDecoded.X.Sign() != 0 || edDecoded.Y.Cmp(big.NewInt(1)) != 0 {
+			t.Errorf("Ed25519 上应该解码为 (0,1), 得到 (%v, %v)", edDecoded.X, edDecoded.Y)
+		}
+
+		zeroText, err := ScalarBaseMult(elliptic.P256(), big.NewInt(0)).MarshalText()
+		if err != nil || string(zeroText) != "inf" {
+			t.Errorf("0·G 应该编码为 inf, 得到 %s (%v)", zeroText, err)
+		}
+	})
+
+	t.Run("坐标不完整", func(t *testing.T) {
+		half := &Point{Curve: elliptic.P256(), X: big.NewInt(1)}
+		if _, err := half.MarshalText(); err == nil {
+			t.Error("应该返回错误当只有一个坐标为 nil")
+		}
+	})
+
+	t.Run("在 JSON 中作为字符串", func(t *testing.T) {
+		p := ScalarBaseMult(elliptic.P256(), big.NewInt(42))
+		data, err := json.Marshal(map[string]*Point{"pub": p})
+		if err != nil {
+			t.Fatalf("json.Marshal 失败: %v", err)
+		}
+		var decoded map[string]*Point
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("json.Unmarshal 失败: %v", err)
+		}
+		if !decoded["pub"].Equal(p) {
+			t.Error("JSON 往返后的点不一致")
+		}
+	})
+
+	t.Run("未知曲线名", func(t *testing.T) {
+		var decoded Point
+		if err := decoded.UnmarshalText([]byte("P-999:1:2")); err == nil {
+			t.Error("应该返回错误当曲线名未知")
+		}
+	})
+
+	t.Run("点不在曲线上", func(t *testing.T) {
+		var decoded Point
+		if err := decoded.UnmarshalText([]byte("P-256:1:2")); err == nil {
+			t.Error("应该返回错误当点不在曲线上")
+		}
+	})
+
+	t.Run("格式错误", func(t *testing.T) {
+		var decoded Point
+		if err := decoded.UnmarshalText([]byte("P-256:zz")); err == nil {
+			t.Error("应该返回错误当格式错误")
+		}
+	})
+}
+
+// ================= 压缩编码测试 =================
+
+func TestPoint_Compressed(t *testing.T) {
+	for _, curve := range testCurves {
+		t.Run(curve.Params().Name, func(t *testing.T) {
+			for _, k := range []int64{1, 2, 3, 7, 12345} {
+				p := ScalarBaseMult(curve, big.NewInt(k))
+				// 覆盖两种 Y 奇偶性
+				for _, pt := range []*Point{p, p.Neg()} {
+					data, err := pt.MarshalCompressed()
+					if err != nil {
+						t.Fatalf("MarshalCompressed 失败: %v", err)
+					}
+					byteLen := (curve.Params().BitSize + 7) / 8
+					if len(data) != 1+byteLen {
+						t.Errorf("编码长度应该是 %d, 得到 %d", 1+byteLen, len(data))
+					}
+					decoded, err := UnmarshalCompressed(curve, data)
+					if err != nil {
+						t.Fatalf("UnmarshalCompressed 失败: %v", err)
+					}
+					if !decoded.Equal(pt) {
+						t.Errorf("k = %d: 解压后的点与原点不相等", k)
+					}
+				}
+			}
+		})
+	}
+
+	t.Run("无穷远点", func(t *testing.T) {
+		curve := elliptic.P256()
+		inf := &Point{Curve: curve}
+		data, err := inf.MarshalCompressed()
+		if err != nil {
+			t.Fatalf("MarshalCompressed 失败: %v", err)
+		}
+		decoded, err := UnmarshalCompressed(curve, data)
+		if err != nil {
+			t.Fatalf("UnmarshalCompressed 失败: %v", err)
+		}
+		if !decoded.IsInfinity() || !decoded.Equal(inf) {
+			t.Error("应该解码为无穷远点")
+		}
+		if decoded.Equal(ScalarBaseMult(curve, big.NewInt(1))) {
+			t.Error("无穷远点不应该等于基点")
+		}
+	})
+
+	t.Run("非法编码", func(t *testing.T) {
+		curve := elliptic.P256()
+		data, _ := ScalarBaseMult(curve, big.NewInt(5)).MarshalCompressed()
+		data[0] = 0x04
+		if _, err := UnmarshalCompressed(curve, data); err == nil {
+			t.Error("应该返回错误当前缀非法")
+		}
+		if _, err := UnmarshalCompressed(curve, data[:10]); err == nil {
+			t.Error("应该返回错误当长度非法")
+		}
+	})
+}
+
+// ================= 哈希到曲线测试 =================
+
+func TestHashToPoint(t *testing.T) {
+	for _, curve := range testCurves {
+		t.Run(curve.Params().Name, func(t *testing.T) {
+			t.Run("确定性", func(t *testing.T) {
+				p1, err := HashToPoint(curve, []byte("label"))
+				if err != nil {
+					t.Fatalf("HashToPoint 失败: %v", err)
+				}
+				p2, err := HashToPoint(curve, []byte("label"))
+				if err != nil {
+					t.Fatalf("HashToPoint 失败: %v", err)
+				}
+				if !p1.Equal(p2) {
+					t.Error("相同 label 应该得到相同的点")
+				}
+			})
+
+			t.Run("不同 label 分布在不同的点上", func(t *testing.T) {
+				seen := make(map[string]bool)
+				for i := 0; i < 20; i++ {
+					label := []byte{'l', byte(i)}
+					p, err := HashToPoint(curve, label)
+					if err != nil {
+						t.Fatalf("HashToPoint 失败: %v", err)
+					}
+					if !p.IsOnCurve() {
+						t.Errorf("label %d 的结果不在曲线上", i)
+					}
+					if p.IsInfinity() {
+						t.Errorf("label %d 的结果是无穷远点", i)
+					}
+					key := p.X.String()
+					if seen[key] {
+						t.Errorf("label %d 的结果与之前重复", i)
+					}
+					seen[key] = true
+				}
+			})
+		})
+	}
+
+	t.Run("nil curve", func(t *testing.T) {
+		if _, err := HashToPoint(nil, []byte("x")); err == nil {
+			t.Error("应该返回错误当 curve 为 nil")
+		}
+	})
+}
+
+func TestDeriveH(t *testing.T) {
+	seen := make(map[string]string)
+	for _, curve := range testCurves {
+		t.Run(curve.Params().Name, func(t *testing.T) {
+			h1, err := DeriveH(curve)
+			if err != nil {
+				t.Fatalf("DeriveH 失败: %v", err)
+			}
+			h2, err := DeriveH(curve)
+			if err != nil {
+				t.Fatalf("DeriveH 失败: %v", err)
+			}
+			if !h1.Equal(h2) {
+				t.Error("多次调用应该得到相同的 H")
+			}
+			if h1.IsInfinity() || !h1.IsOnCurve() {
+				t.Error("H 应该是曲线上的非无穷远点")
+			}
+			g := ScalarBaseMult(curve, big.NewInt(1))
+			if h1.Equal(g) {
+				t.Error("H 不应该等于 G")
+			}
+			key := h1.X.String()
+			if other, ok := seen[key]; ok {
+				t.Errorf("H 与曲线 %s 的相同", other)
+			}
+			seen[key] = curve.Params().Name
+		})
+	}
+
+	t.Run("nil curve", func(t *testing.T) {
+		if _, err := DeriveH(nil); err == nil {
+			t.Error("应该返回错误当 curve 为 nil")
+		}
+	})
+}
+
+func TestElGamalCommit(t *testing.T) {
+	for _, curve := range testCurves {
+		t.Run(curve.Params().Name, func(t *testing.T) {
+			N := curve.Params().N
+			x, err := RandScalar(curve, rand.Reader)
+			if err != nil {
+				t.Fatalf("生成标量失败: %v", err)
+			}
+			H := ScalarBaseMult(curve, x)
+
+			// decrypt 计算 b - x·a，应等于 m·G
+			decrypt := func(a, b *Point) *Point {
+				return b.Sub(a.ScalarMult(x))
+			}
+
+			m1, r1 := big.NewInt(17), big.NewInt(1001)
+			m2, r2 := big.NewInt(25), big.NewInt(2002)
+			a1, b1 := ElGamalCommit(curve, m1, r1, H)
+			a2, b2 := ElGamalCommit(curve, m2, r2, H)
+			if a1 == nil || b1 == nil || a2 == nil || b2 == nil {
+				t.Fatal("承诺不应该为 nil")
+			}
+			if !decrypt(a1, b1).Equal(ScalarBaseMult(curve, m1)) {
+				t.Error("b - x·a 应该等于 m·G")
+			}
+
+			// 同态加法：逐分量相加等于承诺 (m1+m2, r1+r2)
+			sumA, sumB := a1.Add(a2), b1.Add(b2)
+			wantA, wantB := ElGamalCommit(curve, new(big.Int).Add(m1, m2), new(big.Int).Add(r1, r2), H)
+			if !sumA.Equal(wantA) || !sumB.Equal(wantB) {
+				t.Error("承诺之和应该等于和的承诺")
+			}
+			if !decrypt(sumA, sumB).Equal(ScalarBaseMult(curve, big.NewInt(42))) {
+				t.Error("承诺之和应该解密为 m1+m2")
+			}
+
+			// 重新随机化：分量改变，但仍承诺同一个 m
+			s, err := RandScalar(curve, rand.Reader)
+			if err != nil {
+				t.Fatalf("生成标量失败: %v", err)
+			}
+			ra, rb := ElGamalReRandomize(a1, b1, s, H)
+			if ra == nil || rb == nil {
+				t.Fatal("重新随机化结果不应该为 nil")
+			}
+			if ra.Equal(a1) || rb.Equal(b1) {
+				t.Error("重新随机化后的承诺应该与原承诺不同")
+			}
+			if !decrypt(ra, rb).Equal(ScalarBaseMult(curve, m1)) {
+				t.Error("重新随机化后应该仍解密为 m1")
+			}
+			wantA, wantB = ElGamalCommit(curve, m1, new(big.Int).Mod(new(big.Int).Add(r1, s), N), H)
+			if !ra.Equal(wantA) || !rb.Equal(wantB) {
+				t.Error("重新随机化应该等价于随机数 r+s 的承诺")
+			}
+		})
+	}
+
+	t.Run("非法参数", func(t *testing.T) {
+		curve := elliptic.P256()
+		H, err := DeriveH(curve)
+		if err != nil {
+			t.Fatalf("DeriveH 失败: %v", err)
+		}
+		if a, b := ElGamalCommit(curve, big.NewInt(1), big.NewInt(1), nil); a != nil || b != nil {
+			t.Error("H 为 nil 时应该返回 nil")
+		}
+		if a, b := ElGamalCommit(elliptic.P384(), big.NewInt(1), big.NewInt(1), H); a != nil || b != nil {
+			t.Error("H 不在 curve 上时应该返回 nil")
+		}
+		a, b := ElGamalCommit(curve, big.NewInt(1), big.NewInt(1), H)
+		other := ScalarBaseMult(elliptic.P384(), big.NewInt(1))
+		if ra, rb := ElGamalReRandomize(a, b, big.NewInt(1), other); ra != nil || rb != nil {
+			t.Error("曲线不一致时应该返回 nil")
+		}
+
+		offCurve := NewPoint(curve, big.NewInt(1), big.NewInt(2))
+		if a, b := ElGamalCommit(curve, big.NewInt(1), big.NewInt(1), offCurve); a != nil || b != nil {
+			t.Error("H 坐标不满足曲线方程时应该返回 nil")
+		}
+		for name, args := range map[string][3]*Point{
+			"a": {offCurve, b, H},
+			"b": {a, offCurve, H},
+			"H": {a, b, offCurve},
+		} {
+			if ra, rb := ElGamalReRandomize(args[0], args[1], big.NewInt(1), args[2]); ra != nil || rb != nil {
+				t.Errorf("%s 不在曲线上时应该返回 nil", name)
+			}
+		}
+	})
+}
+
+// ================= Ed25519 测试 =================
+
+func TestEd25519(t *testing.T) {
+	curve := Ed25519()
+	params := curve.Params()
+	identity := NewPoint(curve, big.NewInt(0), big.NewInt(1))
+
+	t.Run("基点在曲线上且阶为 L", func(t *testing.T) {
+		if !curve.IsOnCurve(params.Gx, params.Gy) {
+			t.Fatal("基点不在曲线上")
+		}
+		x, y := curve.ScalarBaseMult(params.N.Bytes())
+		if !NewPoint(curve, x, y).Equal(identity) {
+			t.Error("L·B 应该是单位元 (0, 1)")
+		}
+	})
+
+	t.Run("与 crypto/ed25519 公钥一致", func(t *testing.T) {
+		for i := 0; i < 5; i++ {
+			seed := make([]byte, ed25519.SeedSize)
+			seed[0] = byte(i)
+			expected := ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)
+
+			scalar, err := Ed25519ScalarFromSeed(seed)
+			if err != nil {
+				t.Fatalf("Ed25519ScalarFromSeed 失败: %v", err)
+			}
+			got, err := ScalarBaseMult(curve, scalar).MarshalEd25519()
+			if err != nil {
+				t.Fatalf("MarshalEd25519 失败: %v", err)
+			}
+			if !bytes.Equal(got, expected) {
+				t.Errorf("种子 %d: 公钥编码不一致", i)
+			}
+		}
+	})
+
+	t.Run("群运算", func(t *testing.T) {
+		p := ScalarBaseMult(curve, big.NewInt(7))
+		q := ScalarBaseMult(curve, big.NewInt(11))
+		if !p.Add(q).Equal(ScalarBaseMult(curve, big.NewInt(18))) {
+			t.Error("7B + 11B 应该等于 18B")
+		}
+		if !p.Add(p.Neg()).Equal(identity) {
+			t.Error("P + (-P) 应该是单位元")
+		}
+		x, y := curve.Double(p.X, p.Y)
+		if !NewPoint(curve, x, y).Equal(ScalarBaseMult(curve, big.NewInt(14))) {
+			t.Error("Double(7B) 应该等于 14B")
+		}
+		if !p.ScalarMult(big.NewInt(-3)).Equal(ScalarBaseMult(curve, big.NewInt(-21))) {
+			t.Error("(-3)·7B 应该等于 (-21)B")
+		}
+	})
+
+	t.Run("曲线注册", func(t *testing.T) {
+		if c, ok := CurveByName("Ed25519"); !ok || c != curve {
+			t.Error("CurveByName 应该返回 Ed25519")
+		}
+		oid, ok := CurveOID(curve)
+		if !ok {
+			t.Fatal("CurveOID 应该支持 Ed25519")
+		}
+		if c, ok := CurveFromOID(oid); !ok || c != curve {
+			t.Error("CurveFromOID 应该返回 Ed25519")
+		}
+	})
+
+	t.Run("种子长度错误", func(t *testing.T) {
+		if _, err := Ed25519ScalarFromSeed(make([]byte, 31)); err == nil {
+			t.Error("应该返回错误当种子不是 32 字节")
+		}
+	})
+
+	t.Run("不支持的编码", func(t *testing.T) {
+		if _, err := ScalarBaseMult(curve, big.NewInt(2)).MarshalCompressed(); err == nil {
+			t.Error("Ed25519 点的 SEC 1 压缩应该返回错误")
+		}
+		if _, err := HashToPoint(curve, []byte("x")); err == nil {
+			t.Error("Ed25519 上的 HashToPoint 应该返回错误")
+		}
+	})
+}
+
+// ================= 标量域测试 =================
+
+func TestScalarField(t *testing.T) {
+	for _, curve := range testCurves {
+		t.Run(curve.Params().Name, func(t *testing.T) {
+			f := NewScalarField(curve)
+			N := curve.Params().N
+			for i := 0; i < 20; i++ {
+				a, _ := rand.Int(rand.Reader, N)
+				b, _ := rand.Int(rand.Reader, N)
+				if f.Add(a, b).Cmp(mod.ModAdd(a, b, N)) != 0 {
+					t.Fatal("Add 与 mod.ModAdd 不一致")
+				}
+				if f.Sub(a, b).Cmp(mod.ModSub(a, b, N)) != 0 {
+					t.Fatal("Sub 与 mod.ModSub 不一致")
+				}
+				if f.Mul(a, b).Cmp(mod.ModMul(a, b, N)) != 0 {
+					t.Fatal("Mul 与 mod.ModMul 不一致")
+				}
+				inv, err := f.Inverse(b)
+				if err != nil {
+					t.Fatalf("Inverse 失败: %v", err)
+				}
+				if f.Mul(inv, b).Cmp(big.NewInt(1)) != 0 {
+					t.Fatal("b · b^{-1} 应该等于 1")
+				}
+			}
+		})
+	}
+
+	t.Run("Rand 在 [1, N) 内", func(t *testing.T) {
+		// 用很小的 N 让边界值大概率出现
+		f := &ScalarField{N: big.NewInt(5)}
+		seen := make(map[int64]bool)
+		for i := 0; i < 500; i++ {
+			k, err := f.Rand(rand.Reader)
+			if err != nil {
+				t.Fatalf("Rand 失败: %v", err)
+			}
+			if k.Sign() <= 0 || k.Cmp(f.N) >= 0 {
+				t.Fatalf("Rand 结果 %v 不在 [1, N) 内", k)
+			}
+			seen[k.Int64()] = true
+		}
+		if len(seen) != 4 {
+			t.Errorf("应该覆盖 1..4 全部取值, 得到 %d 个", len(seen))
+		}
+	})
+
+	t.Run("0 不可逆", func(t *testing.T) {
+		var noInv *mod.NoInverseError
+		if _, err := NewScalarField(elliptic.P256()).Inverse(big.NewInt(0)); !errors.As(err, &noInv) {
+			t.Errorf("应该返回 NoInverseError, 得到 %v", err)
+		}
+	})
+}
+
+// ================= 固定基预计算表测试 =================
+
+func TestRandScalar(t *testing.T) {
+	for _, curve := range append(testCurves, Ed25519()) {
+		t.Run(curve.Params().Name+" 在 [1, N) 内", func(t *testing.T) {
+			N := curve.Params().N
+			topSet := 0
+			const samples = 400
+			for i := 0; i < samples; i++ {
+				k, err := RandScalar(curve, rand.Reader)
+				if err != nil {
+					t.Fatalf("RandScalar 失败: %v", err)
+				}
+				if k.Sign() <= 0 || k.Cmp(N) >= 0 {
+					t.Fatalf("结果 %v 不在 [1, N) 内", k)
+				}
+				if k.Bit(N.BitLen()-2) == 1 {
+					topSet++
+				}
+			}
+			// 次高位为 1 的比例约为 1/2
+			if topSet < samples/4 || topSet > samples*3/4 {
+				t.Errorf("最高位分布异常: %d/%d", topSet, samples)
+			}
+		})
+	}
+
+	t.Run("小模数均匀性", func(t *testing.T) {
+		N := big.NewInt(7)
+		counts := make([]int, 7)
+		const samples = 7000
+		for i := 0; i < samples; i++ {
+			k, err := randScalar(N, rand.Reader)
+			if err != nil {
+				t.Fatalf("randScalar 失败: %v", err)
+			}
+			counts[k.Int64()]++
+		}
+		if counts[0] != 0 {
+			t.Error("不应该采到 0")
+		}
+		// 每个值期望 1000 次，卡方检验（自由度 5，p = 0.001 时临界值约 20.5）
+		chi2 := 0.0
+		for _, c := range counts[1:] {
+			d := float64(c) - samples/6.0
+			chi2 += d * d / (samples / 6.0)
+		}
+		if chi2 > 20.5 {
+			t.Errorf("分布不均匀: %v, χ² = %.1f", counts[1:], chi2)
+		}
+	})
+
+	t.Run("全零随机源有界失败", func(t *testing.T) {
+		zeros := bytes.NewReader(make([]byte, 1<<16))
+		if _, err := RandScalar(elliptic.P256(), zeros); !errors.Is(err, ErrRandScalar) {
+			t.Errorf("应该返回 ErrRandScalar, 得到 %v", err)
+		}
+	})
+
+	t.Run("随机源耗尽", func(t *testing.T) {
+		if _, err := RandScalar(elliptic.P256(), bytes.NewReader(nil)); err == nil {
+			t.Error("应该返回错误当随机源没有数据")
+		}
+		if _, err := RandScalar(nil, rand.Reader); err == nil {
+			t.Error("应该返回错误当 curve 为 nil")
+		}
+	})
+}
+
+func TestBaseMultTable(t *testing.T) {
+	for _, curve := range append(testCurves, Ed25519()) {
+		t.Run(curve.Params().Name, func(t *testing.T) {
+			table := NewBaseMultTable(curve)
+			N := curve.Params().N
+
+			scalars := []*big.Int{
+				big.NewInt(0),
+				big.NewInt(1),
+				big.NewInt(-1),
+				new(big.Int).Sub(N, big.NewInt(1)),
+				new(big.Int).Add(N, big.NewInt(7)),
+			}
+			for i := 0; i < 100; i++ {
+				k, _ := rand.Int(rand.Reader, N)
+				scalars = append(scalars, k)
+			}
+
+			for _, k := range scalars {
+				got := table.Mult(k)
+				want := ScalarBaseMult(curve, k)
+				if !got.Equal(want) {
+					t.Fatalf("Mult(%v) 与 ScalarBaseMult 不一致", k)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBaseMultTable(b *testing.B) {
+	for _, curve := range append(testCurves, Ed25519()) {
+		k := new(big.Int).Sub(curve.Params().N, big.NewInt(12345))
+		table := NewBaseMultTable(curve)
+		b.Run(curve.Params().Name+"/Table", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				table.Mult(k)
+			}
+		})
+		b.Run(curve.Params().Name+"/ScalarBaseMult", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ScalarBaseMult(curve, k)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package ec
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+
+	"tss-crypto/pkg/mod"
+)
+
+// ScalarField 表示曲线群阶 N 上的标量域 Z_N，集中处理标量的取模运算
+type ScalarField struct {
+	N *big.Int
+}
+
+// NewScalarField 返回曲线群阶上的标量域
+func NewScalarField(curve elliptic.Curve) *ScalarField {
+	return &ScalarField{N: curve.Params().N}
+}
+
+// Add 计算 (a + b) mod N
+func (f *ScalarField) Add(a, b *big.Int) *big.Int {
+	return mod.ModAdd(a, b, f.N)
+}
+
+// Sub 计算 (a - b) mod N，结果在 [0, N) 内
+func (f *ScalarField) Sub(a, b *big.Int) *big.Int {
+	return mod.ModSub(a, b, f.N)
+}
+
+// Mul 计算 (a * b) mod N
+func (f *ScalarField) Mul(a, b *big.Int) *big.Int {
+	return mod.ModMul(a, b, f.N)
+}
+
+// Inverse 计算 a^{-1} mod N，a ≡ 0 时返回 mod.NoInverseError
+func (f *ScalarField) Inverse(a *big.Int) (*big.Int, error) {
+	return mod.ModInverse(a, f.N)
+}
+
+// Rand 在 [1, N) 中均匀采样非零标量，见 RandScalar
+func (f *ScalarField) Rand(random io.Reader) (*big.Int, error) {
+	return randScalar(f.N, random)
+}
+
+// randScalarMaxAttempts 是拒绝采样的次数上限。
+// 每次被拒绝的概率不超过 1/2，128 次全部失败的概率约 2^-128，
+// 只有坏掉的随机源（如始终输出零）才会触发
+const randScalarMaxAttempts = 128
+
+// ErrRandScalar 表示拒绝采样在次数上限内没有得到有效标量，通常意味着随机源异常
+var ErrRandScalar = errors.New("ec: failed to sample a nonzero scalar, random source may be broken")
+
+// RandScalar 在 [1, N) 中均匀采样标量，N 为曲线群阶。
+// 读取 N 的位长那么多的随机位，拒绝 0 和 >= N 的值再重采（无取模偏差）；
+// 重采次数有上限，超过时返回 ErrRandScalar。r 为 nil 时使用 crypto/rand
+func RandScalar(curve elliptic.Curve, r io.Reader) (*big.Int, error) {
+	if curve == nil {
+		return nil, errors.New("ec: curve is nil")
+	}
+	return randScalar(curve.Params().N, r)
+}
+
+// randScalar 在 [1, N) 中拒绝采样
+func randScalar(N *big.Int, r io.Reader) (*big.Int, error) {
+	if r == nil {
+		r = rand.Reader
+	}
+	bitLen := N.BitLen()
+	buf := make([]byte, (bitLen+7)/8)
+	// 最高字节只保留 bitLen 以内的位，使每次被拒绝的概率 < 1/2
+	topMask := byte(0xff)
+	if extra := len(buf)*8 - bitLen; extra > 0 {
+		topMask >>= uint(extra)
+	}
+
+	k := new(big.Int)
+	for i := 0; i < randScalarMaxAttempts; i++ {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		buf[0] &= topMask
+		k.SetBytes(buf)
+		if k.Sign() != 0 && k.Cmp(N) < 0 {
+			return k, nil
+		}
+	}
+	return nil, ErrRandScalar
+}
@@ -0,0 +1,71 @@
+package ec
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// infinityText 是无穷远点的文本编码
+const infinityText = "inf"
+
+// MarshalText 实现 encoding.TextMarshaler，编码为 "曲线名:十六进制X:十六进制Y"
+// 无穷远点（任一表示，见 IsInfinity）编码为 "inf"；只有一个坐标为 nil 的点返回错误
+func (p *Point) MarshalText() ([]byte, error) {
+	if p.IsInfinity() {
+		return []byte(infinityText), nil
+	}
+	if p.X == nil || p.Y == nil {
+		return nil, errors.New("ec: point has a nil coordinate")
+	}
+	if p.Curve == nil {
+		return nil, errors.New("ec: point has no curve")
+	}
+	if _, ok := CurveByName(p.Curve.Params().Name); !ok {
+		return nil, fmt.Errorf("ec: unsupported curve %s", p.Curve.Params().Name)
+	}
+	text := p.Curve.Params().Name + ":" + p.X.Text(16) + ":" + p.Y.Text(16)
+	return []byte(text), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler
+// "inf" 解码为接收者所在曲线的 Identity（Curve 保持不变）；接收者尚无曲线时
+// 无法确定标准表示，只能将 X、Y 置 nil。其余点必须在所指曲线上
+func (p *Point) UnmarshalText(text []byte) error {
+	s := string(text)
+	if s == infinityText {
+		if p.Curve == nil {
+			p.X, p.Y = nil, nil
+			return nil
+		}
+		id := Identity(p.Curve)
+		p.X, p.Y = id.X, id.Y
+		return nil
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return errors.New("ec: point text must be curve:x:y or inf")
+	}
+	curve, ok := CurveByName(parts[0])
+	if !ok {
+		return fmt.Errorf("ec: unknown curve name %q", parts[0])
+	}
+	x, ok := new(big.Int).SetString(parts[1], 16)
+	if !ok {
+		return errors.New("ec: invalid hex x coordinate")
+	}
+	y, ok := new(big.Int).SetString(parts[2], 16)
+	if !ok {
+		return errors.New("ec: invalid hex y coordinate")
+	}
+	if !curve.IsOnCurve(x, y) {
+		return errors.New("ec: point is not on curve")
+	}
+
+	p.Curve = curve
+	p.X = x
+	p.Y = y
+	return nil
+}
@@ -0,0 +1,360 @@
+// Package ecdsa 提供门限 ECDSA 预签名的份额组合与校验，不包含网络层。
+//
+// 预签名流程（GG18 风格，签名方集合 S，|S| >= t）：
+//
+//  1. 每方用 Lagrange 系数把 Shamir 私钥份额 x_i 转成加法份额 w_i = λ_i·x_i，
+//     使 Σ w_i = x；再采样 k_i、γ_i，广播 Enc_i(k_i) 与 Γ_i = γ_i·G；
+//  2. 每对 (i, j) 运行两次 MtA：k_i·γ_j 与 k_i·w_j 分别拆成加法份额，
+//     累加得到 δ_i（Σ δ_i = k·γ）与 σ_i（Σ σ_i = k·x）；
+//  3. 公开 δ_i，R = δ^{-1}·Γ = k^{-1}·G。
+//
+// 各方最终持有 (R, k_i, σ_i)。对消息摘要 m，s_i = m·k_i + r·σ_i 求和即为
+// s = k·(m + r·x)，与 R 构成标准 ECDSA 签名。
+//
+// MtA 不含范围证明（见 pkg/mta），本包只适用于诚实但好奇的参与方模型。
+package ecdsa
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"tss-crypto/pkg/ec"
+	"tss-crypto/pkg/mod"
+	"tss-crypto/pkg/mta"
+	"tss-crypto/pkg/paillier"
+	"tss-crypto/pkg/vss"
+)
+
+// ---- 错误 ----
+
+// 可用 errors.Is 判断的错误类型
+var (
+	ErrNotSigner           = errors.New("ecdsa: share index is not in the signer set")
+	ErrInvalidMessage      = errors.New("ecdsa: invalid presigning message")
+	ErrIncomplete          = errors.New("ecdsa: presigning round incomplete")
+	ErrZeroDelta           = errors.New("ecdsa: combined delta is zero, restart presigning")
+	ErrInvalidPresignature = errors.New("ecdsa: presignature verification failed")
+)
+
+// ---- 消息 ----
+
+// KeyMessage 是第一轮的广播消息
+type KeyMessage struct {
+	From  vss.Index
+	EncK  *big.Int  // Enc_i(k_i)，用发送方的 Paillier 公钥加密
+	Gamma *ec.Point // Γ_i = γ_i·G
+}
+
+// MtAResponse 是第二轮发给单个对端的点对点消息，包含对其 Enc(k) 的两次 MtA 回应
+type MtAResponse struct {
+	From  vss.Index
+	To    vss.Index
+	Delta *big.Int // 对 k_to·γ_from 的回应
+	Sigma *big.Int // 对 k_to·w_from 的回应
+}
+
+// Presignature 是单个签名方持有的预签名材料
+type Presignature struct {
+	Curve elliptic.Curve
+	Index vss.Index
+	R     *ec.Point // k^{-1}·G，各方相同
+	K     *big.Int  // k_i，Σ k_i = k
+	Sigma *big.Int  // σ_i，Σ σ_i = k·x
+}
+
+// PublicShare 是预签名份额的公开承诺，用于 VerifyPresignature：
+// KR = k_i·R，SigmaR = σ_i·R
+type PublicShare struct {
+	KR     *ec.Point
+	SigmaR *ec.Point
+}
+
+// ---- 签名方状态 ----
+
+// Party 保存单个签名方在预签名过程中的本地状态，不可并发使用
+type Party struct {
+	curve   elliptic.Curve
+	index   vss.Index
+	signers []vss.Index
+	priv    *paillier.PrivateKey
+
+	w     *big.Int // 加法私钥份额 λ_i·x_i
+	k     *big.Int
+	gamma *big.Int
+	delta *big.Int // 累加中的 δ_i
+	sigma *big.Int // 累加中的 σ_i
+
+	responded map[string]bool // 已回应过其 KeyMessage 的对端
+	finished  map[string]bool // 已处理过其 MtAResponse 的对端
+}
+
+// NewParty 为持有 share 的签名方创建预签名状态。
+// signers 是本次参与签名的全部下标（含自己），个数不少于 share.Threshold；
+// priv 是本方的 Paillier 私钥，其模数须满足 mta 对曲线阶的要求
+func NewParty(random io.Reader, curve elliptic.Curve, share *vss.Share, signers []vss.Index, priv *paillier.PrivateKey) (*Party, error) {
+	if share == nil || share.Index == nil || share.Value == nil {
+		return nil, errors.New("ecdsa: share is nil")
+	}
+	if priv == nil {
+		return nil, errors.New("ecdsa: paillier key is nil")
+	}
+	signers, err := vss.CheckIndices(curve, signers)
+	if err != nil {
+		return nil, err
+	}
+	if err := vss.ValidateThreshold(share.Threshold, len(signers)); err != nil {
+		return nil, err
+	}
+
+	N := curve.Params().N
+	index := mod.Mod(share.Index, N)
+	pos := -1
+	for i, j := range signers {
+		if j.Cmp(index) == 0 {
+			pos = i
+			break
+		}
+	}
+	if pos < 0 {
+		return nil, fmt.Errorf("index %v: %w", index, ErrNotSigner)
+	}
+	lambdas, err := vss.LagrangeCoefficients(curve, signers, big.NewInt(0))
+	if err != nil {
+		return nil, err
+	}
+	lambda := lambdas[pos]
+
+	field := ec.NewScalarField(curve)
+	k, err := field.Rand(random)
+	if err != nil {
+		return nil, err
+	}
+	gamma, err := field.Rand(random)
+	if err != nil {
+		return nil, err
+	}
+	w := field.Mul(lambda, share.Value)
+
+	return &Party{
+		curve:     curve,
+		index:     index,
+		signers:   signers,
+		priv:      priv,
+		w:         w,
+		k:         k,
+		gamma:     gamma,
+		delta:     field.Mul(k, gamma),
+		sigma:     field.Mul(k, w),
+		responded: make(map[string]bool),
+		finished:  make(map[string]bool),
+	}, nil
+}
+
+// Index 返回本方规范化后的下标
+func (p *Party) Index() vss.Index {
+	return p.index
+}
+
+// PaillierKey 返回本方的 Paillier 公钥，对端回应 KeyMessage 时使用
+func (p *Party) PaillierKey() *paillier.PublicKey {
+	return p.priv.Public()
+}
+
+// Broadcast 第一轮：生成 Enc(k_i) 与 Γ_i = γ_i·G 的广播消息
+func (p *Party) Broadcast(random io.Reader) (*KeyMessage, error) {
+	encK, err := mta.AliceInit(random, p.priv.Public(), p.k, p.curve.Params().N)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyMessage{
+		From:  p.index,
+		EncK:  encK,
+		Gamma: ec.ScalarBaseMult(p.curve, p.gamma),
+	}, nil
+}
+
+// Respond 第二轮：作为 MtA 的 Bob 回应对端的 KeyMessage，peerKey 是发送方的 Paillier 公钥。
+// 本方的 β 份额计入 δ_i、σ_i，返回的消息发给 msg.From
+func (p *Party) Respond(random io.Reader, peerKey *paillier.PublicKey, msg *KeyMessage) (*MtAResponse, error) {
+	if msg == nil || msg.EncK == nil {
+		return nil, fmt.Errorf("key message is incomplete: %w", ErrInvalidMessage)
+	}
+	from, err := p.peer(msg.From)
+	if err != nil {
+		return nil, err
+	}
+	if p.responded[from.String()] {
+		return nil, fmt.Errorf("duplicate key message from %v: %w", from, ErrInvalidMessage)
+	}
+
+	q := p.curve.Params().N
+	ctDelta, betaDelta, err := mta.BobRespond(random, peerKey, msg.EncK, p.gamma, q)
+	if err != nil {
+		return nil, err
+	}
+	ctSigma, betaSigma, err := mta.BobRespond(random, peerKey, msg.EncK, p.w, q)
+	if err != nil {
+		return nil, err
+	}
+
+	p.delta = mod.ModAdd(p.delta, betaDelta, q)
+	p.sigma = mod.ModAdd(p.sigma, betaSigma, q)
+	p.responded[from.String()] = true
+	return &MtAResponse{From: p.index, To: from, Delta: ctDelta, Sigma: ctSigma}, nil
+}
+
+// Finish 第二轮收尾：作为 MtA 的 Alice 解密对端回应，α 份额计入 δ_i、σ_i
+func (p *Party) Finish(resp *MtAResponse) error {
+	if resp == nil || resp.To == nil || resp.Delta == nil || resp.Sigma == nil {
+		return fmt.Errorf("response is incomplete: %w", ErrInvalidMessage)
+	}
+	q := p.curve.Params().N
+	if mod.Mod(resp.To, q).Cmp(p.index) != 0 {
+		return fmt.Errorf("response addressed to %v: %w", resp.To, ErrInvalidMessage)
+	}
+	from, err := p.peer(resp.From)
+	if err != nil {
+		return err
+	}
+	if p.finished[from.String()] {
+		return fmt.Errorf("duplicate response from %v: %w", from, ErrInvalidMessage)
+	}
+
+	alphaDelta, err := mta.AliceFinish(p.priv, resp.Delta, q)
+	if err != nil {
+		return err
+	}
+	alphaSigma, err := mta.AliceFinish(p.priv, resp.Sigma, q)
+	if err != nil {
+		return err
+	}
+
+	p.delta = mod.ModAdd(p.delta, alphaDelta, q)
+	p.sigma = mod.ModAdd(p.sigma, alphaSigma, q)
+	p.finished[from.String()] = true
+	return nil
+}
+
+// DeltaShare 第三轮：返回待公开的 δ_i。所有对端的 MtA 完成前返回 ErrIncomplete
+func (p *Party) DeltaShare() (*big.Int, error) {
+	if err := p.checkComplete(); err != nil {
+		return nil, err
+	}
+	return new(big.Int).Set(p.delta), nil
+}
+
+// Presignature 用 CombineR 得到的 R 生成本方的预签名材料
+func (p *Party) Presignature(R *ec.Point) (*Presignature, error) {
+	if err := p.checkComplete(); err != nil {
+		return nil, err
+	}
+	if R == nil || !ec.SameCurve(R.Curve, p.curve) || R.IsInfinity() {
+		return nil, fmt.Errorf("R is invalid: %w", ErrInvalidMessage)
+	}
+	return &Presignature{
+		Curve: p.curve,
+		Index: p.index,
+		R:     R.Copy(),
+		K:     new(big.Int).Set(p.k),
+		Sigma: new(big.Int).Set(p.sigma),
+	}, nil
+}
+
+// ---- 组合与校验 ----
+
+// CombineR 由所有签名方公开的 δ_i 与 Γ_i 计算 R = (Σ δ_i)^{-1}·(Σ Γ_i) = k^{-1}·G。
+// δ = 0 的概率可忽略，出现时返回 ErrZeroDelta，调用方应重新预签名
+func CombineR(curve elliptic.Curve, deltas []*big.Int, gammas []*ec.Point) (*ec.Point, error) {
+	if len(deltas) == 0 || len(deltas) != len(gammas) {
+		return nil, fmt.Errorf("got %d deltas and %d gammas: %w", len(deltas), len(gammas), ErrInvalidMessage)
+	}
+	field := ec.NewScalarField(curve)
+
+	delta := new(big.Int)
+	var gamma *ec.Point
+	for i := range deltas {
+		if deltas[i] == nil || gammas[i] == nil || !ec.SameCurve(gammas[i].Curve, curve) || !gammas[i].IsOnCurve() {
+			return nil, fmt.Errorf("share %d is invalid: %w", i, ErrInvalidMessage)
+		}
+		delta = field.Add(delta, deltas[i])
+		if gamma == nil {
+			gamma = gammas[i].Copy()
+		} else {
+			gamma = gamma.Add(gammas[i])
+		}
+	}
+
+	deltaInv, err := field.Inverse(delta)
+	if err != nil {
+		return nil, ErrZeroDelta
+	}
+	return gamma.ScalarMult(deltaInv), nil
+}
+
+// Public 返回预签名份额的公开承诺 (k_i·R, σ_i·R)
+func (ps *Presignature) Public() *PublicShare {
+	return &PublicShare{
+		KR:     ps.R.ScalarMult(ps.K),
+		SigmaR: ps.R.ScalarMult(ps.Sigma),
+	}
+}
+
+// VerifyPresignature 用各方的公开承诺检查预签名一致性：
+// Σ k_i·R = k·k^{-1}·G = G，且 Σ σ_i·R = k·x·k^{-1}·G = X（X 为群公钥）
+func VerifyPresignature(curve elliptic.Curve, R, publicKey *ec.Point, shares []*PublicShare) error {
+	if len(shares) == 0 || R == nil || publicKey == nil {
+		return fmt.Errorf("missing inputs: %w", ErrInvalidPresignature)
+	}
+	var sumK, sumSigma *ec.Point
+	for i, s := range shares {
+		if s == nil || s.KR == nil || s.SigmaR == nil {
+			return fmt.Errorf("public share %d is nil: %w", i, ErrInvalidPresignature)
+		}
+		if sumK == nil {
+			sumK, sumSigma = s.KR.Copy(), s.SigmaR.Copy()
+		} else {
+			sumK, sumSigma = sumK.Add(s.KR), sumSigma.Add(s.SigmaR)
+		}
+	}
+
+	params := curve.Params()
+	if !sumK.Equal(ec.NewPoint(curve, params.Gx, params.Gy)) {
+		return fmt.Errorf("sum of k_i·R is not G: %w", ErrInvalidPresignature)
+	}
+	if !sumSigma.Equal(publicKey) {
+		return fmt.Errorf("sum of sigma_i·R is not the public key: %w", ErrInvalidPresignature)
+	}
+	return nil
+}
+
+// ---- 内部实现 ----
+
+// peer 检查发送方是签名集合中的另一方，返回规范化后的发送方下标
+func (p *Party) peer(sender vss.Index) (vss.Index, error) {
+	if sender == nil {
+		return nil, fmt.Errorf("sender is nil: %w", ErrInvalidMessage)
+	}
+	from := mod.Mod(sender, p.curve.Params().N)
+	if from.Cmp(p.index) == 0 {
+		return nil, fmt.Errorf("message from self: %w", ErrInvalidMessage)
+	}
+	for _, s := range p.signers {
+		if s.Cmp(from) == 0 {
+			return from, nil
+		}
+	}
+	return nil, fmt.Errorf("sender %v: %w", from, ErrNotSigner)
+}
+
+// checkComplete 检查已与所有对端完成两个方向的 MtA
+func (p *Party) checkComplete() error {
+	peers := len(p.signers) - 1
+	if len(p.responded) != peers || len(p.finished) != peers {
+		return fmt.Errorf("responded to %d and finished %d of %d peers: %w",
+			len(p.responded), len(p.finished), peers, ErrIncomplete)
+	}
+	return nil
+}
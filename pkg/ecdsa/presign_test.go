@@ -0,0 +1,208 @@
+package ecdsa
+
+import (
+	stdecdsa "crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+	"testing"
+
+	"tss-crypto/pkg/ec"
+	"tss-crypto/pkg/mod"
+	"tss-crypto/pkg/paillier"
+	"tss-crypto/pkg/vss"
+)
+
+// runPresign 在内存中跑完整的三轮预签名，返回各方状态、R 与各方预签名
+func runPresign(t *testing.T, curve elliptic.Curve, shares vss.Shares, keys []*paillier.PrivateKey) ([]*Party, *ec.Point, []*Presignature) {
+	t.Helper()
+	signers := make([]vss.Index, len(shares))
+	for i, s := range shares {
+		signers[i] = s.Index
+	}
+
+	parties := make([]*Party, len(shares))
+	for i, s := range shares {
+		p, err := NewParty(rand.Reader, curve, s, signers, keys[i])
+		if err != nil {
+			t.Fatalf("NewParty 失败: %v", err)
+		}
+		parties[i] = p
+	}
+
+	// 第一轮：广播 Enc(k_i) 与 Γ_i
+	msgs := make([]*KeyMessage, len(parties))
+	for i, p := range parties {
+		msg, err := p.Broadcast(rand.Reader)
+		if err != nil {
+			t.Fatalf("Broadcast 失败: %v", err)
+		}
+		msgs[i] = msg
+	}
+
+	// 第二轮：两两 MtA
+	for i, p := range parties {
+		for j, q := range parties {
+			if i == j {
+				continue
+			}
+			resp, err := q.Respond(rand.Reader, p.PaillierKey(), msgs[i])
+			if err != nil {
+				t.Fatalf("Respond 失败: %v", err)
+			}
+			if err := p.Finish(resp); err != nil {
+				t.Fatalf("Finish 失败: %v", err)
+			}
+		}
+	}
+
+	// 第三轮：公开 δ_i，计算 R
+	deltas := make([]*big.Int, len(parties))
+	gammas := make([]*ec.Point, len(parties))
+	for i, p := range parties {
+		d, err := p.DeltaShare()
+		if err != nil {
+			t.Fatalf("DeltaShare 失败: %v", err)
+		}
+		deltas[i] = d
+		gammas[i] = msgs[i].Gamma
+	}
+	R, err := CombineR(curve, deltas, gammas)
+	if err != nil {
+		t.Fatalf("CombineR 失败: %v", err)
+	}
+
+	presigs := make([]*Presignature, len(parties))
+	for i, p := range parties {
+		ps, err := p.Presignature(R)
+		if err != nil {
+			t.Fatalf("Presignature 失败: %v", err)
+		}
+		presigs[i] = ps
+	}
+	return parties, R, presigs
+}
+
+func TestPresign(t *testing.T) {
+	curve := elliptic.P256()
+	N := curve.Params().N
+
+	keys := make([]*paillier.PrivateKey, 3)
+	for i := range keys {
+		k, err := paillier.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatalf("生成密钥失败: %v", err)
+		}
+		keys[i] = k
+	}
+
+	x, _ := rand.Int(rand.Reader, N)
+	indices := []vss.Index{big.NewInt(1), big.NewInt(2), big.NewInt(3)}
+	commit, shares, err := vss.SplitSecret(curve, 2, x, indices)
+	if err != nil {
+		t.Fatalf("SplitSecret 失败: %v", err)
+	}
+	publicKey := commit.Coeffs[0]
+
+	parties, R, presigs := runPresign(t, curve, shares, keys)
+
+	t.Run("重建 k 与 k·x", func(t *testing.T) {
+		k := new(big.Int)
+		sigma := new(big.Int)
+		for i, ps := range presigs {
+			k = mod.ModAdd(k, ps.K, N)
+			sigma = mod.ModAdd(sigma, ps.Sigma, N)
+			if ps.K.Cmp(parties[i].k) != 0 {
+				t.Errorf("presignature %d 的 K 与本方 k_i 不一致", i)
+			}
+		}
+		if sigma.Cmp(mod.ModMul(k, x, N)) != 0 {
+			t.Error("Σ σ_i 应该等于 k·x")
+		}
+		kInv, _ := mod.ModInverse(k, N)
+		if !R.Equal(ec.ScalarBaseMult(curve, kInv)) {
+			t.Error("R 应该等于 k^{-1}·G")
+		}
+	})
+
+	t.Run("公开校验", func(t *testing.T) {
+		public := make([]*PublicShare, len(presigs))
+		for i, ps := range presigs {
+			public[i] = ps.Public()
+		}
+		if err := VerifyPresignature(curve, R, publicKey, public); err != nil {
+			t.Errorf("VerifyPresignature 失败: %v", err)
+		}
+
+		// 篡改一个 σ_i
+		bad := *presigs[0]
+		bad.Sigma = mod.ModAdd(bad.Sigma, big.NewInt(1), N)
+		public[0] = bad.Public()
+		if err := VerifyPresignature(curve, R, publicKey, public); !errors.Is(err, ErrInvalidPresignature) {
+			t.Errorf("应该返回 ErrInvalidPresignature, 得到 %v", err)
+		}
+	})
+
+	t.Run("组合出标准 ECDSA 签名", func(t *testing.T) {
+		digest := sha256.Sum256([]byte("threshold ecdsa"))
+		m := new(big.Int).SetBytes(digest[:])
+		r := mod.Mod(R.X, N)
+
+		// s = Σ (m·k_i + r·σ_i) = k·(m + r·x)
+		s := new(big.Int)
+		for _, ps := range presigs {
+			s = mod.ModAdd(s, mod.ModAdd(mod.ModMul(m, ps.K, N), mod.ModMul(r, ps.Sigma, N), N), N)
+		}
+
+		pub := &stdecdsa.PublicKey{Curve: curve, X: publicKey.X, Y: publicKey.Y}
+		if !stdecdsa.Verify(pub, digest[:], r, s) {
+			t.Error("组合出的签名应该能通过 crypto/ecdsa 验证")
+		}
+	})
+
+	t.Run("参数错误", func(t *testing.T) {
+		outsider := &vss.Share{Index: big.NewInt(9), Value: big.NewInt(1), Threshold: 2}
+		if _, err := NewParty(rand.Reader, curve, outsider, indices, keys[0]); !errors.Is(err, ErrNotSigner) {
+			t.Errorf("应该返回 ErrNotSigner, 得到 %v", err)
+		}
+		if _, err := NewParty(rand.Reader, curve, shares[0], indices[:1], keys[0]); !errors.Is(err, vss.ErrNotEnoughShares) {
+			t.Errorf("签名方少于门限时应该返回 ErrNotEnoughShares, 得到 %v", err)
+		}
+
+		p, err := NewParty(rand.Reader, curve, shares[0], indices, keys[0])
+		if err != nil {
+			t.Fatalf("NewParty 失败: %v", err)
+		}
+		if _, err := p.DeltaShare(); !errors.Is(err, ErrIncomplete) {
+			t.Errorf("MtA 未完成时应该返回 ErrIncomplete, 得到 %v", err)
+		}
+		msg, _ := p.Broadcast(rand.Reader)
+		if _, err := p.Respond(rand.Reader, keys[0].Public(), msg); !errors.Is(err, ErrInvalidMessage) {
+			t.Errorf("回应自己的消息应该返回 ErrInvalidMessage, 得到 %v", err)
+		}
+	})
+
+	t.Run("参数相同但实例不同的曲线", func(t *testing.T) {
+		params := *curve.Params()
+		rebuiltR := &ec.Point{Curve: &params, X: R.X, Y: R.Y}
+		if _, err := parties[0].Presignature(rebuiltR); err != nil {
+			t.Errorf("曲线参数相同的 R 应该被接受: %v", err)
+		}
+		deltas := make([]*big.Int, len(parties))
+		gammas := make([]*ec.Point, len(parties))
+		for i, p := range parties {
+			deltas[i], _ = p.DeltaShare()
+			g := ec.ScalarBaseMult(curve, p.gamma)
+			gammas[i] = &ec.Point{Curve: &params, X: g.X, Y: g.Y}
+		}
+		combined, err := CombineR(curve, deltas, gammas)
+		if err != nil {
+			t.Fatalf("CombineR 失败: %v", err)
+		}
+		if !combined.Equal(R) {
+			t.Error("曲线参数相同的 Γ_i 应该组合出相同的 R")
+		}
+	})
+}
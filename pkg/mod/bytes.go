@@ -0,0 +1,27 @@
+package mod
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrDoesNotFit 表示整数无法编码进指定宽度（负数或字节数超出）
+var ErrDoesNotFit = errors.New("mod: integer does not fit in the requested width")
+
+// ToFixedBytes 把非负整数编码为恰好 size 字节的大端表示，左侧补零。
+// big.Int.Bytes 的长度随数值变化，定长的线上格式需要用这个函数
+func ToFixedBytes(x *big.Int, size int) ([]byte, error) {
+	if x == nil || x.Sign() < 0 {
+		return nil, fmt.Errorf("%w: integer must be non-negative", ErrDoesNotFit)
+	}
+	if size < 0 || (x.BitLen()+7)/8 > size {
+		return nil, fmt.Errorf("%w: %d-bit integer into %d bytes", ErrDoesNotFit, x.BitLen(), size)
+	}
+	return x.FillBytes(make([]byte, size)), nil
+}
+
+// FromFixedBytes 把大端字节（可带前导零）解析为非负整数，是 ToFixedBytes 的逆
+func FromFixedBytes(b []byte) *big.Int {
+	return new(big.Int).SetBytes(b)
+}
@@ -0,0 +1,53 @@
+package mod
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestFixedBytes(t *testing.T) {
+	t.Run("恰好放下", func(t *testing.T) {
+		x := big.NewInt(0x010203)
+		b, err := ToFixedBytes(x, 3)
+		if err != nil {
+			t.Fatalf("ToFixedBytes 失败: %v", err)
+		}
+		if !bytes.Equal(b, []byte{0x01, 0x02, 0x03}) {
+			t.Errorf("期望 010203, 得到 %x", b)
+		}
+	})
+
+	t.Run("左侧补零", func(t *testing.T) {
+		x := big.NewInt(0xabcd)
+		b, err := ToFixedBytes(x, 6)
+		if err != nil {
+			t.Fatalf("ToFixedBytes 失败: %v", err)
+		}
+		if !bytes.Equal(b, []byte{0, 0, 0, 0, 0xab, 0xcd}) {
+			t.Errorf("期望 00000000abcd, 得到 %x", b)
+		}
+		if FromFixedBytes(b).Cmp(x) != 0 {
+			t.Error("FromFixedBytes 应该还原原值")
+		}
+
+		zero, err := ToFixedBytes(big.NewInt(0), 4)
+		if err != nil || !bytes.Equal(zero, make([]byte, 4)) {
+			t.Errorf("0 应该编码为 4 个零字节, 得到 %x, %v", zero, err)
+		}
+	})
+
+	t.Run("溢出", func(t *testing.T) {
+		x := new(big.Int).Lsh(big.NewInt(1), 64) // 9 字节
+		if _, err := ToFixedBytes(x, 8); !errors.Is(err, ErrDoesNotFit) {
+			t.Errorf("应该返回 ErrDoesNotFit, 得到 %v", err)
+		}
+		if _, err := ToFixedBytes(big.NewInt(-1), 8); !errors.Is(err, ErrDoesNotFit) {
+			t.Errorf("负数应该返回 ErrDoesNotFit, 得到 %v", err)
+		}
+		if _, err := ToFixedBytes(nil, 8); !errors.Is(err, ErrDoesNotFit) {
+			t.Errorf("nil 应该返回 ErrDoesNotFit, 得到 %v", err)
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package mod
+
+import (
+	"crypto/subtle"
+	"math/big"
+)
+
+// ConstantTimeEq 判断 a == b，耗时只取决于两者中较长者的字数，而不取决于数值：
+// 逐字异或并把差异累积到一个字里，最后才做一次判断，不会在第一个不同的字处提前返回。
+// 适合比较份额、明文等秘密值；长度本身（字数）仍会泄露，与 big.Int 的其他运算一致。
+// 任一参数为 nil 时只在两者都为 nil 时返回 true
+func ConstantTimeEq(a, b *big.Int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	aw, bw := a.Bits(), b.Bits()
+	n := len(aw)
+	if len(bw) > n {
+		n = len(bw)
+	}
+
+	var diff big.Word
+	for i := 0; i < n; i++ {
+		var x, y big.Word
+		if i < len(aw) {
+			x = aw[i]
+		}
+		if i < len(bw) {
+			y = bw[i]
+		}
+		diff |= x ^ y
+	}
+
+	// 把 diff 折叠为 0/1，再与符号比较结果合并
+	folded := uint64(diff)
+	folded |= folded >> 32
+	folded |= folded >> 16
+	folded |= folded >> 8
+	folded |= folded >> 4
+	folded |= folded >> 2
+	folded |= folded >> 1
+	wordsEq := int(^folded & 1)
+	signEq := subtle.ConstantTimeEq(int32(a.Sign()), int32(b.Sign()))
+	return wordsEq&signEq == 1
+}
@@ -0,0 +1,67 @@
+package mod
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestConstantTimeEq(t *testing.T) {
+	check := func(t *testing.T, a, b *big.Int) {
+		t.Helper()
+		want := a.Cmp(b) == 0
+		if got := ConstantTimeEq(a, b); got != want {
+			t.Errorf("ConstantTimeEq(%v, %v) = %v, Cmp 给出 %v", a, b, got, want)
+		}
+	}
+
+	t.Run("随机整数对", func(t *testing.T) {
+		bound := new(big.Int).Lsh(big.NewInt(1), 2048)
+		for i := 0; i < 200; i++ {
+			a, _ := rand.Int(rand.Reader, bound)
+			b, _ := rand.Int(rand.Reader, bound)
+			check(t, a, b)
+			check(t, a, new(big.Int).Set(a))
+		}
+	})
+
+	t.Run("只差一个比特", func(t *testing.T) {
+		bound := new(big.Int).Lsh(big.NewInt(1), 1024)
+		for i := 0; i < 100; i++ {
+			a, _ := rand.Int(rand.Reader, bound)
+			for _, bit := range []int{0, 1, 63, 64, 65, 511, 1023, 1024} {
+				b := new(big.Int).SetBit(a, bit, a.Bit(bit)^1)
+				check(t, a, b)
+				if ConstantTimeEq(a, b) {
+					t.Fatalf("翻转第 %d 位后不应该相等", bit)
+				}
+			}
+		}
+	})
+
+	t.Run("长度不同与符号", func(t *testing.T) {
+		big1 := new(big.Int).Lsh(big.NewInt(1), 200)
+		cases := [][2]*big.Int{
+			{big.NewInt(0), big.NewInt(0)},
+			{big.NewInt(0), big.NewInt(1)},
+			{big.NewInt(5), big.NewInt(-5)},
+			{big.NewInt(-5), big.NewInt(-5)},
+			{big1, new(big.Int).Add(big1, big.NewInt(1))},
+			{big1, big.NewInt(1)},
+			{new(big.Int).Neg(big1), big1},
+		}
+		for _, c := range cases {
+			check(t, c[0], c[1])
+			check(t, c[1], c[0])
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		if !ConstantTimeEq(nil, nil) {
+			t.Error("两个 nil 应该相等")
+		}
+		if ConstantTimeEq(nil, big.NewInt(0)) || ConstantTimeEq(big.NewInt(0), nil) {
+			t.Error("nil 与非 nil 不应该相等")
+		}
+	})
+}
@@ -1,45 +1,116 @@
+// Package mod 提供大整数模运算工具。
+//
+// 模数约定：所有函数按 |m| 取模，结果总在 [0, |m|) 内，负模数与其绝对值等价，
+// 不会得到带符号的结果。m = 0 属于调用方错误：返回 *big.Int 的函数会 panic，
+// ModInverse 返回 NoInverseError。
 package mod
 
 import "math/big"
 
+var bigOne = big.NewInt(1)
+
 // ModMul 计算 (a * b) mod m，返回新的大整数
 func ModMul(a, b, m *big.Int) *big.Int {
 	result := new(big.Int).Mul(a, b)
-	result.Mod(result, m)
+	result.Mod(result, modulus(m))
 	return result
 }
 
 // ModAdd 计算 (a + b) mod m，返回新的大整数
 func ModAdd(a, b, m *big.Int) *big.Int {
 	result := new(big.Int).Add(a, b)
-	result.Mod(result, m)
+	result.Mod(result, modulus(m))
 	return result
 }
 
-// ModSub 计算 (a - b) mod m，返回新的大整数（结果保证在 [0, m) 范围内）
+// ModSub 计算 (a - b) mod m，返回新的大整数（结果保证在 [0, |m|) 范围内）
 func ModSub(a, b, m *big.Int) *big.Int {
 	result := new(big.Int).Sub(a, b)
-	result.Mod(result, m)
+	result.Mod(result, modulus(m))
+	return result
+}
+
+// ModSum 计算 (x_1 + x_2 + ... + x_k) mod m，空输入返回 0
+func ModSum(m *big.Int, xs ...*big.Int) *big.Int {
+	m = modulus(m)
+	result := new(big.Int)
+	for _, x := range xs {
+		result.Add(result, x)
+		result.Mod(result, m)
+	}
 	return result
 }
 
-// ModExp 计算 (base^exp) mod m，返回新的大整数
-func ModExp(base, exp, m *big.Int) *big.Int {
-	return new(big.Int).Exp(base, exp, m)
+// ModProduct 计算 (x_1 * x_2 * ... * x_k) mod m，空输入返回 1 mod m
+func ModProduct(m *big.Int, xs ...*big.Int) *big.Int {
+	m = modulus(m)
+	result := new(big.Int).Mod(big.NewInt(1), m)
+	for _, x := range xs {
+		result.Mul(result, x)
+		result.Mod(result, m)
+	}
+	return result
+}
+
+// ModExp 计算 (base^exp) mod m，返回新的大整数；与其他函数一样按 |m| 取模，m = 0 时 panic
+// 负指数按 (base^{-1})^{|exp|} mod m 计算，base 不可逆时返回 NoInverseError
+func ModExp(base, exp, m *big.Int) (*big.Int, error) {
+	m = modulus(m)
+	if exp.Sign() < 0 {
+		inv, err := ModInverse(base, m)
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Int).Exp(inv, new(big.Int).Neg(exp), m), nil
+	}
+	return new(big.Int).Exp(base, exp, m), nil
+}
+
+// ExtGCD 扩展欧几里得算法：返回 g = gcd(a, b) >= 0 以及 Bézout 系数 x、y，满足 a·x + b·y = g。
+// a、b 可以为负数或零；a = b = 0 时 g = x = y = 0
+func ExtGCD(a, b *big.Int) (g, x, y *big.Int) {
+	x, y = new(big.Int), new(big.Int)
+	g = new(big.Int).GCD(x, y, a, b)
+	return g, x, y
 }
 
-// ModInverse 计算 a 在模 m 下的乘法逆元，如果逆元不存在则返回 nil 和错误
+// ModInverse 计算 a 在模 m 下的乘法逆元，如果逆元不存在则返回 nil 和错误。
+// 基于 ExtGCD：gcd(a mod m, m) = 1 时 a·x ≡ 1 (mod m)，返回 x mod m
 func ModInverse(a, m *big.Int) (*big.Int, error) {
-	result := new(big.Int).ModInverse(a, m)
-	if result == nil {
+	if m.Sign() == 0 {
 		return nil, &NoInverseError{A: a, M: m}
 	}
-	return result, nil
+	abs := modulus(m)
+	g, x, _ := ExtGCD(new(big.Int).Mod(a, abs), abs)
+	if g.Cmp(bigOne) != 0 {
+		return nil, &NoInverseError{A: a, M: m}
+	}
+	return x.Mod(x, abs), nil
 }
 
-// Mod 计算 a mod m，返回新的大整数
+// Mod 计算 a mod m，返回新的大整数，结果在 [0, |m|) 内
 func Mod(a, m *big.Int) *big.Int {
-	return new(big.Int).Mod(a, m)
+	return new(big.Int).Mod(a, modulus(m))
+}
+
+// modulus 返回 |m|，m = 0 时以明确的信息 panic（而不是 big.Int 的除零错误）
+func modulus(m *big.Int) *big.Int {
+	switch m.Sign() {
+	case 0:
+		panic("mod: modulus must be non-zero")
+	case -1:
+		return new(big.Int).Abs(m)
+	}
+	return m
+}
+
+// IsUnit 判断 x 是否属于 Z*_N：0 < x < N 且 gcd(x, N) = 1。
+// 与其他函数不同，这里不对 x 取模，越界的 x 直接视为不合法；x 或 N 为 nil 时返回 false
+func IsUnit(x, N *big.Int) bool {
+	if x == nil || N == nil || x.Sign() <= 0 || x.Cmp(N) >= 0 {
+		return false
+	}
+	return new(big.Int).GCD(nil, nil, x, N).Cmp(bigOne) == 0
 }
 
 // NoInverseError 表示模逆元不存在的错误
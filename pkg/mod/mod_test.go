@@ -0,0 +1,379 @@
+package mod
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// ================= 模幂测试 =================
+
+func TestModExp(t *testing.T) {
+	m := big.NewInt(101) // 素数模数
+	base := big.NewInt(7)
+
+	t.Run("非负指数与 big.Int.Exp 一致", func(t *testing.T) {
+		for _, e := range []int64{0, 1, 2, 50, 100} {
+			exp := big.NewInt(e)
+			got, err := ModExp(base, exp, m)
+			if err != nil {
+				t.Fatalf("ModExp 失败: %v", err)
+			}
+			expected := new(big.Int).Exp(base, exp, m)
+			if got.Cmp(expected) != 0 {
+				t.Errorf("exp = %d: 期望 %v, 得到 %v", e, expected, got)
+			}
+		}
+	})
+
+	t.Run("exp = -1 等于 ModInverse", func(t *testing.T) {
+		got, err := ModExp(base, big.NewInt(-1), m)
+		if err != nil {
+			t.Fatalf("ModExp 失败: %v", err)
+		}
+		inv, err := ModInverse(base, m)
+		if err != nil {
+			t.Fatalf("ModInverse 失败: %v", err)
+		}
+		if got.Cmp(inv) != 0 {
+			t.Errorf("期望 %v, 得到 %v", inv, got)
+		}
+	})
+
+	t.Run("exp = -k 等于逆元连乘 k 次", func(t *testing.T) {
+		inv, _ := ModInverse(base, m)
+		expected := big.NewInt(1)
+		for k := int64(1); k <= 10; k++ {
+			expected = ModMul(expected, inv, m)
+			got, err := ModExp(base, big.NewInt(-k), m)
+			if err != nil {
+				t.Fatalf("ModExp 失败: %v", err)
+			}
+			if got.Cmp(expected) != 0 {
+				t.Errorf("exp = -%d: 期望 %v, 得到 %v", k, expected, got)
+			}
+		}
+	})
+
+	t.Run("base 不可逆时返回 NoInverseError", func(t *testing.T) {
+		_, err := ModExp(big.NewInt(6), big.NewInt(-2), big.NewInt(9))
+		var noInv *NoInverseError
+		if !errors.As(err, &noInv) {
+			t.Errorf("应该返回 NoInverseError, 得到 %v", err)
+		}
+	})
+}
+
+// ================= 连加/连乘测试 =================
+
+func TestModSumProduct(t *testing.T) {
+	m, _ := rand.Prime(rand.Reader, 256)
+
+	t.Run("与 ModAdd/ModMul 折叠一致", func(t *testing.T) {
+		for k := 1; k <= 10; k++ {
+			xs := make([]*big.Int, k)
+			for i := range xs {
+				// 包含超过 m 的值和负数
+				xs[i], _ = rand.Int(rand.Reader, new(big.Int).Lsh(m, 2))
+				if i%3 == 0 {
+					xs[i].Neg(xs[i])
+				}
+			}
+			sum, prod := big.NewInt(0), big.NewInt(1)
+			for _, x := range xs {
+				sum = ModAdd(sum, x, m)
+				prod = ModMul(prod, x, m)
+			}
+			if got := ModSum(m, xs...); got.Cmp(sum) != 0 {
+				t.Errorf("k = %d: ModSum 期望 %v, 得到 %v", k, sum, got)
+			}
+			if got := ModProduct(m, xs...); got.Cmp(prod) != 0 {
+				t.Errorf("k = %d: ModProduct 期望 %v, 得到 %v", k, prod, got)
+			}
+		}
+	})
+
+	t.Run("空输入", func(t *testing.T) {
+		if got := ModSum(m); got.Sign() != 0 {
+			t.Errorf("ModSum() 应该是 0, 得到 %v", got)
+		}
+		if got := ModProduct(m); got.Cmp(big.NewInt(1)) != 0 {
+			t.Errorf("ModProduct() 应该是 1, 得到 %v", got)
+		}
+	})
+
+	t.Run("不修改输入", func(t *testing.T) {
+		x := big.NewInt(-7)
+		ModSum(m, x, x)
+		ModProduct(m, x, x)
+		if x.Int64() != -7 {
+			t.Errorf("输入被修改为 %v", x)
+		}
+	})
+}
+
+// ================= 模数约定测试 =================
+
+func TestNegativeModulus(t *testing.T) {
+	pos := big.NewInt(7)
+	neg := big.NewInt(-7)
+	a := big.NewInt(-20)
+	b := big.NewInt(5)
+
+	t.Run("负模数与其绝对值等价", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			got, exp *big.Int
+		}{
+			{"Mod", Mod(a, neg), Mod(a, pos)},
+			{"ModAdd", ModAdd(a, b, neg), ModAdd(a, b, pos)},
+			{"ModSub", ModSub(b, a, neg), ModSub(b, a, pos)},
+			{"ModMul", ModMul(a, b, neg), ModMul(a, b, pos)},
+			{"ModSum", ModSum(neg, a, b, a), ModSum(pos, a, b, a)},
+			{"ModProduct", ModProduct(neg, a, b), ModProduct(pos, a, b)},
+			{"ModExp", mustModExp(t, a, big.NewInt(5), neg), mustModExp(t, a, big.NewInt(5), pos)},
+			{"ModExp 负指数", mustModExp(t, b, big.NewInt(-3), neg), mustModExp(t, b, big.NewInt(-3), pos)},
+		}
+		for _, tc := range cases {
+			if tc.got.Cmp(tc.exp) != 0 {
+				t.Errorf("%s: 负模数结果 %v 应该等于 %v", tc.name, tc.got, tc.exp)
+			}
+			if tc.got.Sign() < 0 || tc.got.Cmp(pos) >= 0 {
+				t.Errorf("%s: 结果 %v 应该在 [0, 7) 内", tc.name, tc.got)
+			}
+		}
+
+		inv, err := ModInverse(big.NewInt(3), neg)
+		if err != nil || inv.Cmp(big.NewInt(5)) != 0 {
+			t.Errorf("3 mod -7 的逆元应该是 5, 得到 %v, %v", inv, err)
+		}
+	})
+
+	t.Run("零模数", func(t *testing.T) {
+		var noInv *NoInverseError
+		if _, err := ModInverse(big.NewInt(3), big.NewInt(0)); !errors.As(err, &noInv) {
+			t.Errorf("ModInverse 应该返回 NoInverseError, 得到 %v", err)
+		}
+
+		cases := map[string]func(){
+			"Mod":        func() { Mod(a, big.NewInt(0)) },
+			"ModExp":     func() { _, _ = ModExp(b, big.NewInt(1<<20), big.NewInt(0)) },
+			"ModExp 负指数": func() { _, _ = ModExp(b, big.NewInt(-1), big.NewInt(0)) },
+		}
+		for name, f := range cases {
+			func() {
+				defer func() {
+					r := recover()
+					if r == nil {
+						t.Fatalf("%s 在模数为 0 时应该 panic", name)
+					}
+					if msg, ok := r.(string); !ok || !strings.Contains(msg, "modulus must be non-zero") {
+						t.Errorf("%s: panic 信息应该说明模数为 0, 得到 %v", name, r)
+					}
+				}()
+				f()
+			}()
+		}
+	})
+}
+
+// mustModExp 调用 ModExp，出错时终止测试
+func mustModExp(t *testing.T, base, exp, m *big.Int) *big.Int {
+	t.Helper()
+	r, err := ModExp(base, exp, m)
+	if err != nil {
+		t.Fatalf("ModExp 失败: %v", err)
+	}
+	return r
+}
+
+// ================= 扩展欧几里得测试 =================
+
+func TestExtGCD(t *testing.T) {
+	checkBezout := func(t *testing.T, a, b *big.Int) {
+		t.Helper()
+		g, x, y := ExtGCD(a, b)
+		if want := new(big.Int).GCD(nil, nil, new(big.Int).Abs(a), new(big.Int).Abs(b)); g.Cmp(want) != 0 {
+			t.Errorf("gcd(%v, %v) 应该是 %v, 得到 %v", a, b, want, g)
+		}
+		lhs := new(big.Int).Mul(a, x)
+		lhs.Add(lhs, new(big.Int).Mul(b, y))
+		if lhs.Cmp(g) != 0 {
+			t.Errorf("a·x + b·y = %v, 应该等于 g = %v (a=%v, b=%v)", lhs, g, a, b)
+		}
+	}
+
+	t.Run("Bézout 等式", func(t *testing.T) {
+		cases := [][2]int64{{240, 46}, {46, 240}, {17, 5}, {-240, 46}, {240, -46}, {0, 7}, {7, 0}, {0, 0}, {1, 1}}
+		for _, c := range cases {
+			checkBezout(t, big.NewInt(c[0]), big.NewInt(c[1]))
+		}
+		bound := new(big.Int).Lsh(big.NewInt(1), 512)
+		for i := 0; i < 50; i++ {
+			a, _ := rand.Int(rand.Reader, bound)
+			b, _ := rand.Int(rand.Reader, bound)
+			checkBezout(t, a, b)
+		}
+	})
+
+	t.Run("ModInverse 与 big.Int.ModInverse 一致", func(t *testing.T) {
+		m, _ := rand.Prime(rand.Reader, 256)
+		m.Mul(m, big.NewInt(3*5*7)) // 合数模数，部分 a 不可逆
+		for i := 0; i < 200; i++ {
+			a, _ := rand.Int(rand.Reader, new(big.Int).Lsh(m, 1))
+			want := new(big.Int).ModInverse(a, m)
+			got, err := ModInverse(a, m)
+			if want == nil {
+				if err == nil {
+					t.Fatalf("ModInverse(%v) 应该返回错误", a)
+				}
+				continue
+			}
+			if err != nil || got.Cmp(want) != 0 {
+				t.Fatalf("ModInverse(%v) = %v, %v, 期望 %v", a, got, err, want)
+			}
+		}
+		got, err := ModInverse(big.NewInt(-3), big.NewInt(7))
+		if err != nil || got.Cmp(big.NewInt(2)) != 0 {
+			t.Errorf("-3 mod 7 的逆元应该是 2, 得到 %v, %v", got, err)
+		}
+	})
+}
+
+func TestIsUnit(t *testing.T) {
+	N := big.NewInt(3 * 5 * 7) // 105
+
+	t.Run("单位元", func(t *testing.T) {
+		for _, x := range []int64{1, 2, 4, 104, 52} {
+			if !IsUnit(big.NewInt(x), N) {
+				t.Errorf("%d 应该是模 %v 的单位元", x, N)
+			}
+		}
+	})
+
+	t.Run("与 N 有公因子", func(t *testing.T) {
+		for _, x := range []int64{3, 5, 7, 15, 21, 35, 70} {
+			if IsUnit(big.NewInt(x), N) {
+				t.Errorf("%d 与 %v 有公因子, 不应该是单位元", x, N)
+			}
+		}
+	})
+
+	t.Run("零", func(t *testing.T) {
+		if IsUnit(big.NewInt(0), N) {
+			t.Error("0 不应该是单位元")
+		}
+	})
+
+	t.Run("越界", func(t *testing.T) {
+		// 106 ≡ 1 (mod 105) 但不在 (0, N) 内
+		for _, x := range []int64{-1, -104, 105, 106} {
+			if IsUnit(big.NewInt(x), N) {
+				t.Errorf("%d 越界, 不应该被接受", x)
+			}
+		}
+	})
+
+	t.Run("nil 与退化模数", func(t *testing.T) {
+		if IsUnit(nil, N) || IsUnit(big.NewInt(1), nil) {
+			t.Error("nil 参数应该返回 false")
+		}
+		if IsUnit(big.NewInt(1), big.NewInt(1)) {
+			t.Error("N = 1 时不存在单位元")
+		}
+	})
+
+	t.Run("与 big.Int.ModInverse 一致", func(t *testing.T) {
+		m, _ := rand.Prime(rand.Reader, 128)
+		m.Mul(m, big.NewInt(3*5*7))
+		for i := 0; i < 200; i++ {
+			x, _ := rand.Int(rand.Reader, m)
+			want := x.Sign() > 0 && new(big.Int).ModInverse(x, m) != nil
+			if got := IsUnit(x, m); got != want {
+				t.Fatalf("IsUnit(%v) = %v, 期望 %v", x, got, want)
+			}
+		}
+	})
+}
+
+// ================= Montgomery 测试 =================
+
+func TestMontgomery(t *testing.T) {
+	m, _ := rand.Prime(rand.Reader, 512)
+	m.Mul(m, m) // 与 N^2 同形的奇数模数
+	mt, err := NewMontgomery(m)
+	if err != nil {
+		t.Fatalf("NewMontgomery 失败: %v", err)
+	}
+
+	t.Run("FromMont(ToMont(x)) = x mod m", func(t *testing.T) {
+		for _, x := range []*big.Int{big.NewInt(0), big.NewInt(1), new(big.Int).Sub(m, big.NewInt(1)), new(big.Int).Add(m, big.NewInt(5))} {
+			got := mt.FromMont(mt.ToMont(x))
+			expected := Mod(x, m)
+			if got.Cmp(expected) != 0 {
+				t.Errorf("x = %v: 期望 %v, 得到 %v", x, expected, got)
+			}
+		}
+	})
+
+	t.Run("MulMont 与 ModMul 一致", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			a, _ := rand.Int(rand.Reader, m)
+			b, _ := rand.Int(rand.Reader, m)
+			got := mt.FromMont(mt.MulMont(mt.ToMont(a), mt.ToMont(b)))
+			expected := ModMul(a, b, m)
+			if got.Cmp(expected) != 0 {
+				t.Fatalf("期望 %v, 得到 %v", expected, got)
+			}
+		}
+	})
+
+	t.Run("非法模数", func(t *testing.T) {
+		for _, bad := range []*big.Int{nil, big.NewInt(0), big.NewInt(1), big.NewInt(100)} {
+			if _, err := NewMontgomery(bad); err == nil {
+				t.Errorf("应该返回错误当 m = %v", bad)
+			}
+		}
+	})
+}
+
+// benchChain 在 N^2 形式的模数下准备 1000 个乘数
+func benchChain(b *testing.B) (*big.Int, []*big.Int) {
+	p, _ := rand.Prime(rand.Reader, 1024)
+	m := new(big.Int).Mul(p, p)
+	xs := make([]*big.Int, 1000)
+	for i := range xs {
+		xs[i], _ = rand.Int(rand.Reader, m)
+	}
+	return m, xs
+}
+
+func BenchmarkMulMont_Chain1000(b *testing.B) {
+	m, xs := benchChain(b)
+	mt, _ := NewMontgomery(m)
+	ys := make([]*big.Int, len(xs))
+	for i, x := range xs {
+		ys[i] = mt.ToMont(x)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		acc := mt.ToMont(big.NewInt(1))
+		for _, y := range ys {
+			acc = mt.MulMont(acc, y)
+		}
+		_ = mt.FromMont(acc)
+	}
+}
+
+func BenchmarkModMul_Chain1000(b *testing.B) {
+	m, xs := benchChain(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		acc := big.NewInt(1)
+		for _, x := range xs {
+			acc = ModMul(acc, x, m)
+		}
+	}
+}
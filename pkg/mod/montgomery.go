@@ -0,0 +1,66 @@
+package mod
+
+import (
+	"errors"
+	"math/big"
+)
+
+// Montgomery 表示固定奇数模数 m 下的 Montgomery 运算上下文，R = 2^k，k = bitlen(m)。
+// 代表元 x̃ = x·R mod m；MulMont 用移位和掩码代替除法取模，
+// 适合在同一模数下做大量连乘（例如 N^2 下的同态运算）
+type Montgomery struct {
+	m     *big.Int
+	k     uint
+	mask  *big.Int // R - 1
+	mPrim *big.Int // -m^{-1} mod R
+}
+
+// NewMontgomery 为奇数模数 m > 1 构造 Montgomery 上下文
+func NewMontgomery(m *big.Int) (*Montgomery, error) {
+	if m == nil || m.Cmp(big.NewInt(1)) <= 0 || m.Bit(0) == 0 {
+		return nil, errors.New("montgomery modulus must be odd and greater than 1")
+	}
+	k := uint(m.BitLen())
+	r := new(big.Int).Lsh(big.NewInt(1), k)
+	mInv := new(big.Int).ModInverse(m, r)
+	mPrim := new(big.Int).Sub(r, mInv)
+	return &Montgomery{
+		m:     new(big.Int).Set(m),
+		k:     k,
+		mask:  new(big.Int).Sub(r, big.NewInt(1)),
+		mPrim: mPrim,
+	}, nil
+}
+
+// ToMont 将 x 转换为 Montgomery 代表元 x·R mod m
+func (mt *Montgomery) ToMont(x *big.Int) *big.Int {
+	result := new(big.Int).Lsh(x, mt.k)
+	result.Mod(result, mt.m)
+	return result
+}
+
+// FromMont 将 Montgomery 代表元转换回普通表示
+func (mt *Montgomery) FromMont(x *big.Int) *big.Int {
+	return mt.redc(new(big.Int).Set(x))
+}
+
+// MulMont 计算两个代表元的 Montgomery 积 a·b·R^{-1} mod m，结果仍为代表元
+func (mt *Montgomery) MulMont(a, b *big.Int) *big.Int {
+	return mt.redc(new(big.Int).Mul(a, b))
+}
+
+// redc 原地计算 t·R^{-1} mod m，要求 0 <= t < m·R
+func (mt *Montgomery) redc(t *big.Int) *big.Int {
+	// u = (t mod R)·m' mod R
+	u := new(big.Int).And(t, mt.mask)
+	u.Mul(u, mt.mPrim)
+	u.And(u, mt.mask)
+	// t = (t + u·m) / R
+	u.Mul(u, mt.m)
+	t.Add(t, u)
+	t.Rsh(t, mt.k)
+	if t.Cmp(mt.m) >= 0 {
+		t.Sub(t, mt.m)
+	}
+	return t
+}
@@ -0,0 +1,126 @@
+package mta
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"tss-crypto/pkg/mod"
+	"tss-crypto/pkg/paillier"
+)
+
+// Gilboa MtA（乘法到加法转换）：
+//
+//	Alice 持有 a，Bob 持有 b，协议结束后 Alice 得到 α、Bob 得到 β，
+//	满足 α + β ≡ a·b (mod q)，q 通常为曲线阶。
+//
+//	1. Alice: cA = Enc(a)                        → Bob
+//	2. Bob:   取随机 β' ∈ [0, q^5)，
+//	          cB = cA^b · g^{β'} · Enc(0)         → Alice，β = -β' mod q
+//	3. Alice: α = Dec(cB) mod q
+//
+// 由于 a, b < q 且 a·b + β' < q^2 + q^5 < N，解密结果不会在模 N 下回绕，
+// 因此 Dec(cB) = a·b + β' 在整数上成立。Enc(0) 用于重新随机化，
+// 防止 Alice 利用 cA 的随机数从 cB 推断 b。
+// 本实现不包含 GG18 等协议要求的范围证明，调用方需自行保证对手行为受约束。
+
+// betaExponent 为 β' 取值上界 q^betaExponent 的指数
+const betaExponent = 5
+
+// 可用 errors.Is 判断的错误类型
+var (
+	ErrInputOutOfRange  = errors.New("mta: input must satisfy 0 <= x < q")
+	ErrModulusTooSmall  = errors.New("mta: paillier modulus too small for curve order")
+	ErrInvalidParameter = errors.New("mta: invalid parameter")
+)
+
+// AliceInit 第一步：Alice 加密自己的乘数 a，返回发送给 Bob 的密文
+func AliceInit(random io.Reader, pub *paillier.PublicKey, a, q *big.Int) (*big.Int, error) {
+	if err := checkParams(pub, q); err != nil {
+		return nil, err
+	}
+	if err := checkInput(a, q); err != nil {
+		return nil, err
+	}
+	return pub.Encrypt(random, a)
+}
+
+// BobRespond 第二步：Bob 用自己的乘数 b 回应 Alice 的密文，
+// 返回发送给 Alice 的密文和 Bob 的加法份额 β
+func BobRespond(random io.Reader, pub *paillier.PublicKey, ctA, b, q *big.Int) (*big.Int, *big.Int, error) {
+	if err := checkParams(pub, q); err != nil {
+		return nil, nil, err
+	}
+	if err := checkInput(b, q); err != nil {
+		return nil, nil, err
+	}
+
+	betaPrime, err := rand.Int(random, new(big.Int).Exp(q, big.NewInt(betaExponent), nil))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// cB = cA^b · g^{β'}
+	ctB, err := pub.Mul(ctA, b)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctB, err = pub.AddConstant(ctB, betaPrime)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 乘以 Enc(0) 重新随机化
+	zero, err := pub.EncryptZero(random)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctB, err = pub.Add(ctB, zero)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	beta := mod.Mod(new(big.Int).Neg(betaPrime), q)
+	return ctB, beta, nil
+}
+
+// AliceFinish 第三步：Alice 解密 Bob 的回应，返回 Alice 的加法份额 α
+func AliceFinish(priv *paillier.PrivateKey, ctB, q *big.Int) (*big.Int, error) {
+	if priv == nil {
+		return nil, fmt.Errorf("private key is nil: %w", ErrInvalidParameter)
+	}
+	if err := checkParams(priv.Public(), q); err != nil {
+		return nil, err
+	}
+	m, err := priv.Decrypt(ctB)
+	if err != nil {
+		return nil, err
+	}
+	return mod.Mod(m, q), nil
+}
+
+// checkParams 检查公钥和 q，并确保 N > q^5 + q^2，使解密结果不回绕
+func checkParams(pub *paillier.PublicKey, q *big.Int) error {
+	if pub == nil || pub.N == nil {
+		return fmt.Errorf("public key is nil: %w", ErrInvalidParameter)
+	}
+	if q == nil || q.Sign() <= 0 {
+		return fmt.Errorf("q must be positive: %w", ErrInvalidParameter)
+	}
+	bound := new(big.Int).Exp(q, big.NewInt(betaExponent), nil)
+	bound.Add(bound, new(big.Int).Mul(q, q))
+	if pub.N.Cmp(bound) <= 0 {
+		return ErrModulusTooSmall
+	}
+	return nil
+}
+
+// checkInput 检查 0 <= x < q
+func checkInput(x, q *big.Int) error {
+	if x == nil || x.Sign() < 0 || x.Cmp(q) >= 0 {
+		return ErrInputOutOfRange
+	}
+	return nil
+}
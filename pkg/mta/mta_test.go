@@ -0,0 +1,76 @@
+package mta
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+
+	"tss-crypto/pkg/paillier"
+)
+
+func TestMtA(t *testing.T) {
+	priv, err := paillier.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+	pub := priv.Public()
+	q := elliptic.P256().Params().N
+
+	run := func(t *testing.T, a, b *big.Int) {
+		t.Helper()
+		ctA, err := AliceInit(rand.Reader, pub, a, q)
+		if err != nil {
+			t.Fatalf("AliceInit 失败: %v", err)
+		}
+		ctB, beta, err := BobRespond(rand.Reader, pub, ctA, b, q)
+		if err != nil {
+			t.Fatalf("BobRespond 失败: %v", err)
+		}
+		alpha, err := AliceFinish(priv, ctB, q)
+		if err != nil {
+			t.Fatalf("AliceFinish 失败: %v", err)
+		}
+
+		sum := new(big.Int).Add(alpha, beta)
+		sum.Mod(sum, q)
+		expected := new(big.Int).Mul(a, b)
+		expected.Mod(expected, q)
+		if sum.Cmp(expected) != 0 {
+			t.Errorf("α + β 应该等于 a·b mod q: 期望 %v, 得到 %v", expected, sum)
+		}
+	}
+
+	t.Run("随机输入 α + β ≡ a·b (mod q)", func(t *testing.T) {
+		for i := 0; i < 5; i++ {
+			a, _ := rand.Int(rand.Reader, q)
+			b, _ := rand.Int(rand.Reader, q)
+			run(t, a, b)
+		}
+	})
+
+	t.Run("边界输入", func(t *testing.T) {
+		qMinusOne := new(big.Int).Sub(q, big.NewInt(1))
+		run(t, big.NewInt(0), qMinusOne)
+		run(t, qMinusOne, qMinusOne)
+	})
+
+	t.Run("输入超出范围", func(t *testing.T) {
+		if _, err := AliceInit(rand.Reader, pub, q, q); !errors.Is(err, ErrInputOutOfRange) {
+			t.Errorf("应该返回 ErrInputOutOfRange, 得到 %v", err)
+		}
+		ctA, _ := AliceInit(rand.Reader, pub, big.NewInt(1), q)
+		if _, _, err := BobRespond(rand.Reader, pub, ctA, big.NewInt(-1), q); !errors.Is(err, ErrInputOutOfRange) {
+			t.Errorf("应该返回 ErrInputOutOfRange, 得到 %v", err)
+		}
+	})
+
+	t.Run("Paillier 模数相对 q 过小", func(t *testing.T) {
+		// q^5 约 2560 位，超过 2048 位模数
+		bigQ := new(big.Int).Lsh(big.NewInt(1), 512)
+		if _, err := AliceInit(rand.Reader, pub, big.NewInt(1), bigQ); !errors.Is(err, ErrModulusTooSmall) {
+			t.Errorf("应该返回 ErrModulusTooSmall, 得到 %v", err)
+		}
+	})
+}
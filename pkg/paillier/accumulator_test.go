@@ -0,0 +1,112 @@
+package paillier
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestAccumulator(t *testing.T) {
+	priv := testKey(t)
+	pub := priv.Public()
+
+	t.Run("初始为 Enc(0)", func(t *testing.T) {
+		m, err := priv.Decrypt(pub.NewAccumulator().Sum())
+		if err != nil {
+			t.Fatalf("解密失败: %v", err)
+		}
+		if m.Sign() != 0 {
+			t.Errorf("期望 0, 得到 %v", m)
+		}
+	})
+
+	t.Run("流式累加 1000 个密文", func(t *testing.T) {
+		acc := pub.NewAccumulator()
+		folded, err := pub.Encrypt(rand.Reader, big.NewInt(0))
+		if err != nil {
+			t.Fatalf("加密失败: %v", err)
+		}
+		foldedFromOne := big.NewInt(1)
+		expected := new(big.Int)
+		for i := 0; i < 1000; i++ {
+			m := big.NewInt(int64(i * 7))
+			expected.Add(expected, m)
+			c, err := pub.Encrypt(rand.Reader, m)
+			if err != nil {
+				t.Fatalf("加密失败: %v", err)
+			}
+			if err := acc.Add(c); err != nil {
+				t.Fatalf("Add 失败: %v", err)
+			}
+			if folded, err = pub.Add(folded, c); err != nil {
+				t.Fatalf("pub.Add 失败: %v", err)
+			}
+			if foldedFromOne, err = pub.Add(foldedFromOne, c); err != nil {
+				t.Fatalf("pub.Add 失败: %v", err)
+			}
+		}
+
+		if acc.Sum().Cmp(foldedFromOne) != 0 {
+			t.Error("累加器结果应该与从 1 开始的链式 Add 完全相同")
+		}
+		got, err := priv.Decrypt(acc.Sum())
+		if err != nil {
+			t.Fatalf("解密失败: %v", err)
+		}
+		want, err := priv.Decrypt(folded)
+		if err != nil {
+			t.Fatalf("解密失败: %v", err)
+		}
+		if got.Cmp(expected) != 0 || want.Cmp(expected) != 0 {
+			t.Errorf("期望 %v, 得到累加器 %v, 链式 Add %v", expected, got, want)
+		}
+	})
+
+	t.Run("Sum 返回副本", func(t *testing.T) {
+		acc := pub.NewAccumulator()
+		s := acc.Sum()
+		s.SetInt64(12345)
+		if acc.Sum().Cmp(big.NewInt(1)) != 0 {
+			t.Error("修改 Sum 的返回值不应该影响累加器")
+		}
+	})
+
+	t.Run("拒绝无效密文", func(t *testing.T) {
+		acc := pub.NewAccumulator()
+		for _, c := range []*big.Int{nil, big.NewInt(0), pub.N2, pub.N} {
+			if err := acc.Add(c); !errors.Is(err, ErrCiphertextInvalid) {
+				t.Errorf("应该返回 ErrCiphertextInvalid, 得到 %v", err)
+			}
+		}
+		if acc.Sum().Cmp(big.NewInt(1)) != 0 {
+			t.Error("失败的 Add 不应该改变累加结果")
+		}
+	})
+}
+
+func BenchmarkAccumulator(b *testing.B) {
+	priv, cs := benchCiphertexts(b)
+	acc := priv.Public().NewAccumulator()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := acc.Add(cs[i%len(cs)]); err != nil {
+			b.Fatalf("累加失败: %v", err)
+		}
+	}
+}
+
+func BenchmarkAccumulator_ChainedAdd(b *testing.B) {
+	priv, cs := benchCiphertexts(b)
+	pub := priv.Public()
+	sum := big.NewInt(1)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var err error
+		if sum, err = pub.Add(sum, cs[i%len(cs)]); err != nil {
+			b.Fatalf("累加失败: %v", err)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package paillier
+
+import (
+	"math/big"
+
+	"tss-crypto/pkg/mod"
+)
+
+// -----------------------------------------------------------------------------
+// 密文类型
+// -----------------------------------------------------------------------------
+
+// Ciphertext 是不透明的密文句柄，记录其所属公钥。
+// 只能通过 Homomorphic 的 Encrypt/EncryptWithRandomness 或 FromBytes 构造，
+// 调用方不直接接触底层 *big.Int，避免把明文或其他密钥的密文误当作本密钥的密文使用。
+// 需要与 *big.Int 接口互操作时使用 BigInt
+type Ciphertext struct {
+	pub *PublicKey
+	c   *big.Int
+}
+
+// FromBytes 将大端字节解析为属于 pub 的密文。
+// 要求 1 <= c < N^2 且 gcd(c, N) = 1，否则返回 ErrCiphertextInvalid；
+// pub 为 nil 或未初始化 N、N^2 时返回 ErrModulusInvalid
+func FromBytes(pub *PublicKey, data []byte) (*Ciphertext, error) {
+	if pub == nil || pub.N == nil || pub.N2 == nil {
+		return nil, ErrModulusInvalid
+	}
+	c := new(big.Int).SetBytes(data)
+	if !pub.IsValidCiphertext(c) {
+		return nil, ErrCiphertextInvalid
+	}
+	return &Ciphertext{pub: pub, c: c}, nil
+}
+
+// Bytes 返回密文的大端字节表示，左侧补零到 CiphertextLen() 字节，
+// 使同一公钥下的所有密文编码长度相同
+func (ct *Ciphertext) Bytes() []byte {
+	// c < N^2，总能放进 CiphertextLen() 字节
+	out, _ := mod.ToFixedBytes(ct.c, ct.pub.CiphertextLen())
+	return out
+}
+
+// BigInt 返回密文底层整数的副本，用于与 *big.Int 接口互操作
+func (ct *Ciphertext) BigInt() *big.Int {
+	return new(big.Int).Set(ct.c)
+}
+
+// PublicKey 返回密文所属的公钥
+func (ct *Ciphertext) PublicKey() *PublicKey {
+	return ct.pub
+}
+
+// Add 同态加法：返回 Enc(m1 + m2)，两个密文必须属于同一公钥
+func (ct *Ciphertext) Add(other *Ciphertext) (*Ciphertext, error) {
+	if err := ct.sameKeyAs(other); err != nil {
+		return nil, err
+	}
+	c, err := ct.pub.Add(ct.c, other.c)
+	if err != nil {
+		return nil, err
+	}
+	return &Ciphertext{pub: ct.pub, c: c}, nil
+}
+
+// Sub 同态减法：返回 Enc(m1 - m2 mod N)，两个密文必须属于同一公钥
+func (ct *Ciphertext) Sub(other *Ciphertext) (*Ciphertext, error) {
+	if err := ct.sameKeyAs(other); err != nil {
+		return nil, err
+	}
+	c, err := ct.pub.Sub(ct.c, other.c)
+	if err != nil {
+		return nil, err
+	}
+	return &Ciphertext{pub: ct.pub, c: c}, nil
+}
+
+// Mul 同态标量乘法：返回 Enc(k * m)
+func (ct *Ciphertext) Mul(k *big.Int) (*Ciphertext, error) {
+	if ct == nil || ct.c == nil {
+		return nil, ErrCiphertextInvalid
+	}
+	c, err := ct.pub.Mul(ct.c, k)
+	if err != nil {
+		return nil, err
+	}
+	return &Ciphertext{pub: ct.pub, c: c}, nil
+}
+
+// sameKeyAs 确认两个密文都非空且属于同一公钥
+func (ct *Ciphertext) sameKeyAs(other *Ciphertext) error {
+	if ct == nil || ct.c == nil || other == nil || other.c == nil {
+		return ErrCiphertextInvalid
+	}
+	if !sameKey(ct.pub, other.pub) {
+		return ErrKeyMismatch
+	}
+	return nil
+}
+
+// sameKey 判断两个公钥是否相同（同一对象或模数相同）
+func sameKey(a, b *PublicKey) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return a.N.Cmp(b.N) == 0
+}
@@ -0,0 +1,146 @@
+package paillier
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestCiphertext(t *testing.T) {
+	priv := testKey1024(t)
+	h := priv.Homomorphic()
+
+	t.Run("Add/Sub/Mul", func(t *testing.T) {
+		c1, _ := h.Encrypt(rand.Reader, big.NewInt(50))
+		c2, _ := h.Encrypt(rand.Reader, big.NewInt(8))
+
+		sum, err := c1.Add(c2)
+		if err != nil {
+			t.Fatalf("Add 失败: %v", err)
+		}
+		diff, err := c1.Sub(c2)
+		if err != nil {
+			t.Fatalf("Sub 失败: %v", err)
+		}
+		prod, err := c2.Mul(big.NewInt(3))
+		if err != nil {
+			t.Fatalf("Mul 失败: %v", err)
+		}
+		neg, err := c2.Sub(c1)
+		if err != nil {
+			t.Fatalf("Sub 失败: %v", err)
+		}
+
+		cases := []struct {
+			name     string
+			ct       *Ciphertext
+			expected *big.Int
+		}{
+			{"50 + 8", sum, big.NewInt(58)},
+			{"50 - 8", diff, big.NewInt(42)},
+			{"8 * 3", prod, big.NewInt(24)},
+			{"8 - 50 (mod N)", neg, new(big.Int).Sub(priv.N, big.NewInt(42))},
+		}
+		for _, tc := range cases {
+			m, err := h.Decrypt(tc.ct)
+			if err != nil {
+				t.Fatalf("%s: 解密失败: %v", tc.name, err)
+			}
+			if m.Cmp(tc.expected) != 0 {
+				t.Errorf("%s: 期望 %v, 得到 %v", tc.name, tc.expected, m)
+			}
+		}
+	})
+
+	t.Run("定长编码", func(t *testing.T) {
+		pub := priv.Public()
+		if pub.CiphertextLen() != (pub.N2.BitLen()+7)/8 || pub.PlaintextLen() != (pub.N.BitLen()+7)/8 {
+			t.Fatalf("长度不对: CiphertextLen=%d, PlaintextLen=%d", pub.CiphertextLen(), pub.PlaintextLen())
+		}
+		// 小于 N^2/256 的密文的 Bytes() 会短于 CiphertextLen，这里直接构造一个
+		small, err := FromBytes(pub, []byte{0x01, 0x02, 0x03})
+		if err != nil {
+			t.Fatalf("FromBytes 失败: %v", err)
+		}
+		cts := []*Ciphertext{small}
+		for i := 0; i < 16; i++ {
+			ct, err := h.Encrypt(rand.Reader, big.NewInt(int64(i)))
+			if err != nil {
+				t.Fatalf("加密失败: %v", err)
+			}
+			cts = append(cts, ct)
+		}
+		for i, ct := range cts {
+			if got := len(ct.Bytes()); got != pub.CiphertextLen() {
+				t.Errorf("密文 %d 编码长度应该是 %d, 得到 %d", i, pub.CiphertextLen(), got)
+			}
+		}
+		decoded, err := FromBytes(pub, small.Bytes())
+		if err != nil || decoded.BigInt().Cmp(small.BigInt()) != 0 {
+			t.Error("补零后的编码应该能往返")
+		}
+	})
+
+	t.Run("FromBytes 往返", func(t *testing.T) {
+		ct, _ := h.Encrypt(rand.Reader, big.NewInt(7))
+		decoded, err := FromBytes(priv.Public(), ct.Bytes())
+		if err != nil {
+			t.Fatalf("FromBytes 失败: %v", err)
+		}
+		m, err := h.Decrypt(decoded)
+		if err != nil {
+			t.Fatalf("Decrypt 失败: %v", err)
+		}
+		if m.Int64() != 7 {
+			t.Errorf("期望 7, 得到 %v", m)
+		}
+	})
+
+	t.Run("FromBytes 拒绝非法值", func(t *testing.T) {
+		pub := priv.Public()
+		cases := []struct {
+			name string
+			c    *big.Int
+		}{
+			{"0", big.NewInt(0)},
+			{"N^2", pub.N2},
+			{"N^2 + 1", new(big.Int).Add(pub.N2, bigOne)},
+			{"与 N 不互素", new(big.Int).Set(priv.P)},
+		}
+		for _, tc := range cases {
+			if _, err := FromBytes(pub, tc.c.Bytes()); !errors.Is(err, ErrCiphertextInvalid) {
+				t.Errorf("%s: 应该返回 ErrCiphertextInvalid, 得到 %v", tc.name, err)
+			}
+		}
+	})
+
+	t.Run("FromBytes 拒绝无效公钥", func(t *testing.T) {
+		data := priv.Public().N.Bytes()
+		for name, pub := range map[string]*PublicKey{
+			"nil":      nil,
+			"零值":       {},
+			"N2 为 nil": {N: priv.Public().N},
+		} {
+			if _, err := FromBytes(pub, data); !errors.Is(err, ErrModulusInvalid) {
+				t.Errorf("%s: 应该返回 ErrModulusInvalid, 得到 %v", name, err)
+			}
+		}
+	})
+
+	t.Run("不同密钥的密文运算返回 ErrKeyMismatch", func(t *testing.T) {
+		other := testKey1024(t).Homomorphic()
+		mine, _ := h.Encrypt(rand.Reader, big.NewInt(1))
+		theirs, _ := other.Encrypt(rand.Reader, big.NewInt(1))
+
+		if _, err := mine.Add(theirs); !errors.Is(err, ErrKeyMismatch) {
+			t.Errorf("Add 应该返回 ErrKeyMismatch, 得到 %v", err)
+		}
+		if _, err := mine.Sub(theirs); !errors.Is(err, ErrKeyMismatch) {
+			t.Errorf("Sub 应该返回 ErrKeyMismatch, 得到 %v", err)
+		}
+		if _, err := h.Sub(theirs, mine); !errors.Is(err, ErrKeyMismatch) {
+			t.Errorf("Homomorphic.Sub 应该返回 ErrKeyMismatch, 得到 %v", err)
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package paillier
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// -----------------------------------------------------------------------------
+// 密文比较（DGK 风格的掩码步骤）
+// -----------------------------------------------------------------------------
+//
+// 发起方 A 持有 Enc(m1)、Enc(m2)（0 <= m1, m2 < 2^ℓ），私钥方 B 持有私钥，
+// 双方想得到 [m1 >= m2] 而不暴露 m1、m2：
+//
+//  1. A：d = 2^ℓ + m1 - m2 ∈ (0, 2^{ℓ+1})，m1 >= m2 当且仅当 d 的第 ℓ 位为 1。
+//     A 采样 r ∈ [0, 2^{ℓ+κ})，发送 Enc(z) = Enc(d + r)（Mask）；
+//  2. B：解密得到 z，只保留 z 的第 ℓ 位 z_ℓ 和低位 z mod 2^ℓ（Open）；
+//  3. 双方运行 DGK 按位比较协议得到 t = [z mod 2^ℓ < r mod 2^ℓ]（本文件不包含这一步）；
+//  4. d_ℓ = z_ℓ ⊕ r_ℓ ⊕ t（GreaterOrEqual）。
+//
+// r 比 d 多 κ 位，z 在统计意义上不泄露 d。
+
+// compareStatBits 是掩码 r 相对 d 额外的统计安全位数 κ
+const compareStatBits = 40
+
+// CompareHelper 封装比较协议中的同态运算和掩码步骤，ℓ 为明文位数上界
+type CompareHelper struct {
+	pub  *PublicKey
+	bits int
+}
+
+// CompareMask 是发起方在 Mask 中使用的掩码 r，须保密保存直到比较结束
+type CompareMask struct {
+	r    *big.Int
+	bits int
+}
+
+// MaskedDifference 是私钥方从 Enc(z) 中保留的部分：z 的低 ℓ 位与第 ℓ 位
+type MaskedDifference struct {
+	Low *big.Int // z mod 2^ℓ，作为 DGK 比较的输入
+	Bit uint     // z_ℓ
+}
+
+// NewCompareHelper 为明文位数 bits（ℓ）创建比较辅助器，要求 N 足够大使 z 不在模 N 下回绕
+func NewCompareHelper(pub *PublicKey, bits int) (*CompareHelper, error) {
+	if pub == nil || pub.N == nil {
+		return nil, errors.New("paillier: public key is nil")
+	}
+	if bits < 1 {
+		return nil, fmt.Errorf("paillier: comparison bit length %d must be positive", bits)
+	}
+	// z < 2^{ℓ+1} + 2^{ℓ+κ} <= 2^{ℓ+κ+1}
+	if pub.N.BitLen() <= bits+compareStatBits+1 {
+		return nil, fmt.Errorf("%w: %d-bit N cannot mask %d-bit comparisons", ErrModulusTooSmall, pub.N.BitLen(), bits)
+	}
+	return &CompareHelper{pub: pub, bits: bits}, nil
+}
+
+// Mask 发起方：由 Enc(m1)、Enc(m2) 计算重新随机化的 Enc(2^ℓ + m1 - m2 + r)，
+// 返回发给私钥方的密文和本方保存的掩码。调用方需保证 m1、m2 < 2^ℓ
+func (h *CompareHelper) Mask(random io.Reader, c1, c2 *big.Int) (*big.Int, *CompareMask, error) {
+	r, err := rand.Int(random, new(big.Int).Lsh(bigOne, uint(h.bits+compareStatBits)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	diff, err := h.pub.Sub(c1, c2)
+	if err != nil {
+		return nil, nil, err
+	}
+	// 2^ℓ + r
+	offset := new(big.Int).Lsh(bigOne, uint(h.bits))
+	offset.Add(offset, r)
+	masked, err := h.pub.AddConstant(diff, offset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 乘以 Enc(0) 重新随机化，使私钥方无法关联 c1、c2 的随机数
+	zero, err := h.pub.EncryptZero(random)
+	if err != nil {
+		return nil, nil, err
+	}
+	masked, err = h.pub.Add(masked, zero)
+	if err != nil {
+		return nil, nil, err
+	}
+	return masked, &CompareMask{r: r, bits: h.bits}, nil
+}
+
+// Open 私钥方：解密 Enc(z)，只返回 z 的低 ℓ 位与第 ℓ 位
+func (h *CompareHelper) Open(priv *PrivateKey, masked *big.Int) (*MaskedDifference, error) {
+	if priv == nil || !sameKey(&priv.PublicKey, h.pub) {
+		return nil, ErrKeyMismatch
+	}
+	z, err := priv.Decrypt(masked)
+	if err != nil {
+		return nil, err
+	}
+	return &MaskedDifference{
+		Low: lowBits(z, h.bits),
+		Bit: z.Bit(h.bits),
+	}, nil
+}
+
+// Low 返回 r mod 2^ℓ，作为 DGK 比较中发起方的输入
+func (m *CompareMask) Low() *big.Int {
+	return lowBits(m.r, m.bits)
+}
+
+// Bit 返回 r 的第 ℓ 位
+func (m *CompareMask) Bit() uint {
+	return m.r.Bit(m.bits)
+}
+
+// GreaterOrEqual 由 z_ℓ、r_ℓ 与 DGK 比较结果 borrow = [z mod 2^ℓ < r mod 2^ℓ]
+// 得到 d_ℓ = z_ℓ ⊕ r_ℓ ⊕ borrow，即 [m1 >= m2]
+func GreaterOrEqual(z *MaskedDifference, mask *CompareMask, borrow bool) bool {
+	bit := z.Bit ^ mask.Bit()
+	if borrow {
+		bit ^= 1
+	}
+	return bit == 1
+}
+
+// lowBits 返回 x mod 2^bits
+func lowBits(x *big.Int, bits int) *big.Int {
+	mask := new(big.Int).Lsh(bigOne, uint(bits))
+	mask.Sub(mask, bigOne)
+	return mask.And(mask, x)
+}
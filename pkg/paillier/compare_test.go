@@ -0,0 +1,76 @@
+package paillier
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestCompareHelper(t *testing.T) {
+	priv := testKey(t)
+	pub := priv.Public()
+	const bits = 32
+
+	h, err := NewCompareHelper(pub, bits)
+	if err != nil {
+		t.Fatalf("NewCompareHelper 失败: %v", err)
+	}
+
+	// compare 跑完整的掩码比较，DGK 按位比较一步用明文比较代替
+	compare := func(t *testing.T, m1, m2 *big.Int) bool {
+		t.Helper()
+		c1, _ := pub.Encrypt(rand.Reader, m1)
+		c2, _ := pub.Encrypt(rand.Reader, m2)
+
+		masked, mask, err := h.Mask(rand.Reader, c1, c2)
+		if err != nil {
+			t.Fatalf("Mask 失败: %v", err)
+		}
+		z, err := h.Open(priv, masked)
+		if err != nil {
+			t.Fatalf("Open 失败: %v", err)
+		}
+		borrow := z.Low.Cmp(mask.Low()) < 0
+		return GreaterOrEqual(z, mask, borrow)
+	}
+
+	max := new(big.Int).Sub(new(big.Int).Lsh(bigOne, bits), bigOne)
+	pairs := [][2]*big.Int{
+		{big.NewInt(5), big.NewInt(3)},
+		{big.NewInt(3), big.NewInt(5)},
+		{big.NewInt(7), big.NewInt(7)},
+		{big.NewInt(0), big.NewInt(0)},
+		{big.NewInt(0), max},
+		{max, big.NewInt(0)},
+		{max, max},
+	}
+	for i := 0; i < 20; i++ {
+		a, _ := rand.Int(rand.Reader, new(big.Int).Add(max, bigOne))
+		b, _ := rand.Int(rand.Reader, new(big.Int).Add(max, bigOne))
+		pairs = append(pairs, [2]*big.Int{a, b})
+	}
+
+	t.Run("符号正确", func(t *testing.T) {
+		for _, p := range pairs {
+			want := p[0].Cmp(p[1]) >= 0
+			if got := compare(t, p[0], p[1]); got != want {
+				t.Errorf("[%v >= %v] 应该是 %v, 得到 %v", p[0], p[1], want, got)
+			}
+		}
+	})
+
+	t.Run("参数错误", func(t *testing.T) {
+		if _, err := NewCompareHelper(pub, pub.N.BitLen()); !errors.Is(err, ErrModulusTooSmall) {
+			t.Errorf("位数过大时应该返回 ErrModulusTooSmall, 得到 %v", err)
+		}
+		if _, err := NewCompareHelper(pub, 0); err == nil {
+			t.Error("应该返回错误当位数为 0")
+		}
+		c, _ := pub.Encrypt(rand.Reader, big.NewInt(1))
+		masked, _, _ := h.Mask(rand.Reader, c, c)
+		if _, err := h.Open(testKey(t), masked); !errors.Is(err, ErrKeyMismatch) {
+			t.Errorf("私钥不匹配时应该返回 ErrKeyMismatch, 得到 %v", err)
+		}
+	})
+}
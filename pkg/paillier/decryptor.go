@@ -0,0 +1,119 @@
+package paillier
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"tss-crypto/pkg/mod"
+)
+
+// -----------------------------------------------------------------------------
+// CRT 解密器
+// -----------------------------------------------------------------------------
+
+// Decryptor 持有一次性预计算好的 CRT 参数，适合对大量密文重复解密。
+// 分别在 mod p^2 和 mod q^2 下求幂（指数 p-1、q-1，长度约为 λ 的一半），
+// 再用 CRT 合并，比 PrivateKey.Decrypt 的 mod N^2 求幂快约 3~4 倍。
+// Decrypt 与 Destroy 可以并发调用：Destroy 会等待进行中的解密结束后再清零参数
+type Decryptor struct {
+	priv *PrivateKey
+
+	// mu 保护下面的预计算参数：Decrypt 持读锁，Destroy 持写锁
+	mu sync.RWMutex
+
+	p, q     *big.Int
+	pp, qq   *big.Int // p^2, q^2
+	pm1, qm1 *big.Int // p-1, q-1
+	muP, muQ *big.Int // L_p(g^{p-1} mod p^2)^{-1} mod p，q 侧同理
+	pInvQ    *big.Int // p^{-1} mod q，CRT 系数
+}
+
+// NewDecryptor 为私钥预计算 CRT 解密参数。私钥 Destroy 时会一并销毁它创建的解密器
+func (priv *PrivateKey) NewDecryptor() (*Decryptor, error) {
+	if priv.destroyed() || priv.P == nil || priv.Q == nil {
+		return nil, ErrKeyDestroyed
+	}
+	d := &Decryptor{
+		priv: priv,
+		p:    new(big.Int).Set(priv.P),
+		q:    new(big.Int).Set(priv.Q),
+	}
+	d.pp = new(big.Int).Mul(d.p, d.p)
+	d.qq = new(big.Int).Mul(d.q, d.q)
+	d.pm1 = new(big.Int).Sub(d.p, bigOne)
+	d.qm1 = new(big.Int).Sub(d.q, bigOne)
+
+	var err error
+	if d.muP, err = crtMu(priv.G, d.p, d.pp, d.pm1); err != nil {
+		return nil, err
+	}
+	if d.muQ, err = crtMu(priv.G, d.q, d.qq, d.qm1); err != nil {
+		return nil, err
+	}
+	if d.pInvQ, err = mod.ModInverse(d.p, d.q); err != nil {
+		return nil, errors.New("paillier: p is not invertible mod q")
+	}
+
+	priv.decryptorsMu.Lock()
+	priv.decryptors = append(priv.decryptors, d)
+	priv.decryptorsMu.Unlock()
+	return d, nil
+}
+
+// Decrypt 解密密文 c，结果与 PrivateKey.Decrypt 相同
+func (d *Decryptor) Decrypt(c *big.Int) (*big.Int, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	// 私钥 Destroy 时会先销毁它创建的解密器，这里只需检查自身的参数
+	if d.p == nil {
+		return nil, ErrKeyDestroyed
+	}
+	if !d.priv.IsValidCiphertext(c) {
+		return nil, ErrCiphertextInvalid
+	}
+
+	// m_p = L_p(c^{p-1} mod p^2) * mu_p mod p
+	mp := L(new(big.Int).Exp(c, d.pm1, d.pp), d.p)
+	mp = mod.ModMul(mp, d.muP, d.p)
+	// m_q = L_q(c^{q-1} mod q^2) * mu_q mod q
+	mq := L(new(big.Int).Exp(c, d.qm1, d.qq), d.q)
+	mq = mod.ModMul(mq, d.muQ, d.q)
+
+	// CRT: m = m_p + p * ((m_q - m_p) * p^{-1} mod q)
+	h := mod.ModMul(mod.ModSub(mq, mp, d.q), d.pInvQ, d.q)
+	return h.Mul(h, d.p).Add(h, mp), nil
+}
+
+// Destroy 清除解密器中的预计算参数并将其从私钥的登记中移除，之后 Decrypt 返回 ErrKeyDestroyed
+func (d *Decryptor) Destroy() {
+	d.priv.decryptorsMu.Lock()
+	for i, other := range d.priv.decryptors {
+		if other == d {
+			d.priv.decryptors = append(d.priv.decryptors[:i], d.priv.decryptors[i+1:]...)
+			break
+		}
+	}
+	d.priv.decryptorsMu.Unlock()
+	d.wipe()
+}
+
+// wipe 在写锁下清零并置空预计算参数
+func (d *Decryptor) wipe() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, x := range []*big.Int{d.p, d.q, d.pp, d.qq, d.pm1, d.qm1, d.muP, d.muQ, d.pInvQ} {
+		zeroInt(x)
+	}
+	d.p, d.q, d.pp, d.qq, d.pm1, d.qm1, d.muP, d.muQ, d.pInvQ = nil, nil, nil, nil, nil, nil, nil, nil, nil
+}
+
+// crtMu 计算 L_r(g^{r-1} mod r^2)^{-1} mod r
+func crtMu(g, r, rr, rm1 *big.Int) (*big.Int, error) {
+	u := L(new(big.Int).Exp(g, rm1, rr), r)
+	inv, err := mod.ModInverse(u, r)
+	if err != nil {
+		return nil, errors.New("paillier: cannot invert L(g^(r-1)) mod r")
+	}
+	return inv, nil
+}
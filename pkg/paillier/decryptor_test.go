@@ -0,0 +1,149 @@
+package paillier
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+)
+
+func TestDecryptor(t *testing.T) {
+	priv := testKey1024(t)
+	pub := priv.Public()
+
+	d, err := priv.NewDecryptor()
+	if err != nil {
+		t.Fatalf("NewDecryptor 失败: %v", err)
+	}
+
+	t.Run("与 PrivateKey.Decrypt 结果一致", func(t *testing.T) {
+		ms := []*big.Int{big.NewInt(0), big.NewInt(1), new(big.Int).Sub(priv.N, bigOne)}
+		for i := 0; i < 20; i++ {
+			m, _ := rand.Int(rand.Reader, priv.N)
+			ms = append(ms, m)
+		}
+		for _, m := range ms {
+			c, _ := pub.Encrypt(rand.Reader, m)
+			got, err := d.Decrypt(c)
+			if err != nil {
+				t.Fatalf("Decryptor.Decrypt 失败: %v", err)
+			}
+			expected, _ := priv.Decrypt(c)
+			if got.Cmp(expected) != 0 || got.Cmp(m) != 0 {
+				t.Errorf("期望 %v, 得到 %v", m, got)
+			}
+		}
+	})
+
+	t.Run("无效密文", func(t *testing.T) {
+		if _, err := d.Decrypt(priv.P); !errors.Is(err, ErrCiphertextInvalid) {
+			t.Errorf("应该返回 ErrCiphertextInvalid, 得到 %v", err)
+		}
+	})
+
+	t.Run("销毁后不可用", func(t *testing.T) {
+		other := testKey1024(t)
+		od, _ := other.NewDecryptor()
+		c, _ := other.Encrypt(rand.Reader, big.NewInt(5))
+
+		p, q := od.p, od.q
+		other.Destroy()
+		if _, err := od.Decrypt(c); !errors.Is(err, ErrKeyDestroyed) {
+			t.Errorf("私钥销毁后应该返回 ErrKeyDestroyed, 得到 %v", err)
+		}
+		if od.p != nil || od.q != nil || od.pInvQ != nil || p.Sign() != 0 || q.Sign() != 0 {
+			t.Error("私钥销毁后解密器中的 p、q 副本应该被清零")
+		}
+		if _, err := other.NewDecryptor(); !errors.Is(err, ErrKeyDestroyed) {
+			t.Errorf("应该返回 ErrKeyDestroyed, 得到 %v", err)
+		}
+
+		d2, _ := priv.NewDecryptor()
+		d2.Destroy()
+		c2, _ := pub.Encrypt(rand.Reader, big.NewInt(5))
+		if _, err := d2.Decrypt(c2); !errors.Is(err, ErrKeyDestroyed) {
+			t.Errorf("解密器销毁后应该返回 ErrKeyDestroyed, 得到 %v", err)
+		}
+	})
+
+	t.Run("销毁后从私钥中注销", func(t *testing.T) {
+		other := testKey1024(t)
+		d1, _ := other.NewDecryptor()
+		d2, _ := other.NewDecryptor()
+		d1.Destroy()
+		d1.Destroy() // 重复销毁是安全的
+		other.decryptorsMu.Lock()
+		n := len(other.decryptors)
+		other.decryptorsMu.Unlock()
+		if n != 1 {
+			t.Fatalf("销毁一个解密器后私钥应该只登记 1 个, 得到 %d", n)
+		}
+
+		// 私钥销毁时仍会清除剩余的解密器
+		other.Destroy()
+		c, _ := other.Encrypt(rand.Reader, big.NewInt(5))
+		if _, err := d2.Decrypt(c); !errors.Is(err, ErrKeyDestroyed) {
+			t.Errorf("私钥销毁后应该返回 ErrKeyDestroyed, 得到 %v", err)
+		}
+	})
+
+	t.Run("并发解密与销毁", func(t *testing.T) {
+		other := testKey1024(t)
+		od, _ := other.NewDecryptor()
+		c, _ := other.Encrypt(rand.Reader, big.NewInt(7))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 20; j++ {
+					m, err := od.Decrypt(c)
+					if err == nil && m.Cmp(big.NewInt(7)) != 0 {
+						t.Errorf("解密结果应该是 7, 得到 %v", m)
+						return
+					}
+					if err != nil && !errors.Is(err, ErrKeyDestroyed) {
+						t.Errorf("应该返回 ErrKeyDestroyed, 得到 %v", err)
+						return
+					}
+				}
+			}()
+		}
+		other.Destroy()
+		wg.Wait()
+	})
+}
+
+// benchCiphertexts 生成一批密文，用于比较两种解密方式
+func benchCiphertexts(b *testing.B) (*PrivateKey, []*big.Int) {
+	priv, _ := GenerateKey(rand.Reader, 2048)
+	cs := make([]*big.Int, 64)
+	for i := range cs {
+		m, _ := rand.Int(rand.Reader, priv.N)
+		cs[i], _ = priv.Encrypt(rand.Reader, m)
+	}
+	return priv, cs
+}
+
+func BenchmarkDecryptor(b *testing.B) {
+	priv, cs := benchCiphertexts(b)
+	d, _ := priv.NewDecryptor()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := d.Decrypt(cs[i%len(cs)]); err != nil {
+			b.Fatalf("解密失败: %v", err)
+		}
+	}
+}
+
+func BenchmarkDecryptor_Plain(b *testing.B) {
+	priv, cs := benchCiphertexts(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := priv.Decrypt(cs[i%len(cs)]); err != nil {
+			b.Fatalf("解密失败: %v", err)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package paillier
+
+import (
+	"io"
+	"math/big"
+)
+
+// -----------------------------------------------------------------------------
+// 同态加密抽象
+// -----------------------------------------------------------------------------
+
+// HomomorphicEncrypter 是只持有公钥一方可用的加法同态操作
+type HomomorphicEncrypter interface {
+	// Encrypt 加密明文 m
+	Encrypt(random io.Reader, m *big.Int) (*Ciphertext, error)
+	// EncryptWithRandomness 用指定随机数加密明文 m
+	EncryptWithRandomness(m, r *big.Int) (*Ciphertext, error)
+	// Add 返回 Enc(m1 + m2)
+	Add(c1, c2 *Ciphertext) (*Ciphertext, error)
+	// Sub 返回 Enc(m1 - m2)
+	Sub(c1, c2 *Ciphertext) (*Ciphertext, error)
+	// Mul 返回 Enc(k * m)
+	Mul(c *Ciphertext, k *big.Int) (*Ciphertext, error)
+}
+
+// Homomorphic 是完整的加法同态加密方案，在 HomomorphicEncrypter 之上增加解密。
+// 协议代码依赖该接口即可与具体后端（Paillier 或未来的 ElGamal）解耦
+type Homomorphic interface {
+	HomomorphicEncrypter
+	// Decrypt 解密密文，返回明文
+	Decrypt(c *Ciphertext) (*big.Int, error)
+}
+
+// Homomorphic 返回以该公钥实现的 HomomorphicEncrypter
+func (pub *PublicKey) Homomorphic() HomomorphicEncrypter {
+	return publicScheme{pub: pub}
+}
+
+// Homomorphic 返回以该私钥实现的 Homomorphic
+func (priv *PrivateKey) Homomorphic() Homomorphic {
+	return privateScheme{publicScheme: publicScheme{pub: &priv.PublicKey}, priv: priv}
+}
+
+// publicScheme 将 PublicKey 包装为 HomomorphicEncrypter
+type publicScheme struct {
+	pub *PublicKey
+}
+
+func (s publicScheme) Encrypt(random io.Reader, m *big.Int) (*Ciphertext, error) {
+	c, err := s.pub.Encrypt(random, m)
+	if err != nil {
+		return nil, err
+	}
+	return &Ciphertext{pub: s.pub, c: c}, nil
+}
+
+func (s publicScheme) EncryptWithRandomness(m, r *big.Int) (*Ciphertext, error) {
+	c, err := s.pub.EncryptWithRandomness(m, r)
+	if err != nil {
+		return nil, err
+	}
+	return &Ciphertext{pub: s.pub, c: c}, nil
+}
+
+func (s publicScheme) Add(c1, c2 *Ciphertext) (*Ciphertext, error) {
+	if err := s.check(c1); err != nil {
+		return nil, err
+	}
+	return c1.Add(c2)
+}
+
+func (s publicScheme) Sub(c1, c2 *Ciphertext) (*Ciphertext, error) {
+	if err := s.check(c1); err != nil {
+		return nil, err
+	}
+	return c1.Sub(c2)
+}
+
+func (s publicScheme) Mul(ct *Ciphertext, k *big.Int) (*Ciphertext, error) {
+	if err := s.check(ct); err != nil {
+		return nil, err
+	}
+	return ct.Mul(k)
+}
+
+// check 确认密文非空且属于该公钥
+func (s publicScheme) check(ct *Ciphertext) error {
+	if ct == nil || ct.c == nil {
+		return ErrCiphertextInvalid
+	}
+	if !sameKey(s.pub, ct.pub) {
+		return ErrKeyMismatch
+	}
+	return nil
+}
+
+// privateScheme 将 PrivateKey 包装为 Homomorphic
+type privateScheme struct {
+	publicScheme
+	priv *PrivateKey
+}
+
+func (s privateScheme) Decrypt(ct *Ciphertext) (*big.Int, error) {
+	if err := s.check(ct); err != nil {
+		return nil, err
+	}
+	return s.priv.Decrypt(ct.c)
+}
@@ -0,0 +1,143 @@
+package paillier
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+// testKey1024 生成 1024 位测试密钥，仅用于加速测试
+func testKey1024(t *testing.T) *PrivateKey {
+	t.Helper()
+	priv, err := GenerateKeyInsecure(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+	return priv
+}
+
+// testKeyBits 是功能测试使用的模数位数，只有依赖密钥长度的测试才生成 2048 位密钥
+const testKeyBits = 512
+
+// testKey 生成 testKeyBits 位的测试密钥
+func testKey(t *testing.T) *PrivateKey {
+	t.Helper()
+	priv, err := GenerateKeyInsecure(rand.Reader, testKeyBits)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+	return priv
+}
+
+// sumOf 通过接口计算 Enc(a) + k*Enc(b) 并解密，只依赖 Homomorphic
+func sumOf(t *testing.T, h Homomorphic, a, b, k *big.Int) *big.Int {
+	t.Helper()
+	ca, err := h.Encrypt(rand.Reader, a)
+	if err != nil {
+		t.Fatalf("Encrypt 失败: %v", err)
+	}
+	cb, err := h.Encrypt(rand.Reader, b)
+	if err != nil {
+		t.Fatalf("Encrypt 失败: %v", err)
+	}
+	kb, err := h.Mul(cb, k)
+	if err != nil {
+		t.Fatalf("Mul 失败: %v", err)
+	}
+	sum, err := h.Add(ca, kb)
+	if err != nil {
+		t.Fatalf("Add 失败: %v", err)
+	}
+	m, err := h.Decrypt(sum)
+	if err != nil {
+		t.Fatalf("Decrypt 失败: %v", err)
+	}
+	return m
+}
+
+func TestHomomorphic(t *testing.T) {
+	priv := testKey1024(t)
+	h := priv.Homomorphic()
+
+	t.Run("通过接口的同态运算", func(t *testing.T) {
+		a, b, k := big.NewInt(123), big.NewInt(456), big.NewInt(7)
+		got := sumOf(t, h, a, b, k)
+		expected := new(big.Int).Add(a, new(big.Int).Mul(b, k))
+		if got.Cmp(expected) != 0 {
+			t.Errorf("期望 %v, 得到 %v", expected, got)
+		}
+	})
+
+	t.Run("与具体方法结果一致", func(t *testing.T) {
+		m := big.NewInt(99)
+		ct, err := h.Encrypt(rand.Reader, m)
+		if err != nil {
+			t.Fatalf("Encrypt 失败: %v", err)
+		}
+
+		// 接口的 Mul 与 PublicKey.Mul 对同一底层密文结果相同
+		k := big.NewInt(5)
+		viaIface, err := h.Mul(ct, k)
+		if err != nil {
+			t.Fatalf("Mul 失败: %v", err)
+		}
+		viaConcrete, err := priv.Mul(ct.BigInt(), k)
+		if err != nil {
+			t.Fatalf("PublicKey.Mul 失败: %v", err)
+		}
+		if viaIface.BigInt().Cmp(viaConcrete) != 0 {
+			t.Error("接口 Mul 与 PublicKey.Mul 结果不一致")
+		}
+
+		// 接口的 Decrypt 与 PrivateKey.Decrypt 结果相同
+		m1, err := h.Decrypt(ct)
+		if err != nil {
+			t.Fatalf("Decrypt 失败: %v", err)
+		}
+		m2, err := priv.Decrypt(ct.BigInt())
+		if err != nil {
+			t.Fatalf("PrivateKey.Decrypt 失败: %v", err)
+		}
+		if m1.Cmp(m) != 0 || m2.Cmp(m) != 0 {
+			t.Errorf("期望 %v, 得到 %v 和 %v", m, m1, m2)
+		}
+	})
+
+	t.Run("公钥侧加密可由私钥侧解密", func(t *testing.T) {
+		enc := priv.Public().Homomorphic()
+		ct, err := enc.Encrypt(rand.Reader, big.NewInt(42))
+		if err != nil {
+			t.Fatalf("Encrypt 失败: %v", err)
+		}
+		m, err := h.Decrypt(ct)
+		if err != nil {
+			t.Fatalf("Decrypt 失败: %v", err)
+		}
+		if m.Int64() != 42 {
+			t.Errorf("期望 42, 得到 %v", m)
+		}
+	})
+
+	t.Run("不同密钥的密文返回 ErrKeyMismatch", func(t *testing.T) {
+		other := testKey1024(t).Homomorphic()
+		ct, _ := other.Encrypt(rand.Reader, big.NewInt(1))
+		mine, _ := h.Encrypt(rand.Reader, big.NewInt(1))
+
+		if _, err := h.Add(mine, ct); !errors.Is(err, ErrKeyMismatch) {
+			t.Errorf("Add 应该返回 ErrKeyMismatch, 得到 %v", err)
+		}
+		if _, err := h.Mul(ct, big.NewInt(2)); !errors.Is(err, ErrKeyMismatch) {
+			t.Errorf("Mul 应该返回 ErrKeyMismatch, 得到 %v", err)
+		}
+		if _, err := h.Decrypt(ct); !errors.Is(err, ErrKeyMismatch) {
+			t.Errorf("Decrypt 应该返回 ErrKeyMismatch, 得到 %v", err)
+		}
+	})
+
+	t.Run("nil 密文返回 ErrCiphertextInvalid", func(t *testing.T) {
+		if _, err := h.Decrypt(nil); !errors.Is(err, ErrCiphertextInvalid) {
+			t.Errorf("应该返回 ErrCiphertextInvalid, 得到 %v", err)
+		}
+	})
+}
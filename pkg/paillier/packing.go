@@ -0,0 +1,74 @@
+package paillier
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// -----------------------------------------------------------------------------
+// 明文打包
+// -----------------------------------------------------------------------------
+//
+// 把 k 个小整数按固定的 slotBits 位槽拼进一个明文：m = Σ v_i · 2^{i·slotBits}，
+// 第 0 个值在最低位。两个打包密文同态相加时各槽逐个相加，
+// 只要每个槽的和仍小于 2^slotBits 就不会向高位的槽进位。
+// 若每个值不超过 valueBits 位，槽宽留出 slotBits - valueBits 位余量，
+// 最多可以把 MaxPackedSums(valueBits, slotBits) 个打包密文相加。
+
+// ErrSlotOverflow 表示某个值放不进槽（负数或不小于 2^slotBits）
+var ErrSlotOverflow = errors.New("paillier: value does not fit in slot")
+
+// PackPlaintext 把 values 按 slotBits 位一槽打包成一个明文，要求 0 <= v < 2^slotBits
+func PackPlaintext(values []*big.Int, slotBits int) (*big.Int, error) {
+	if slotBits < 1 {
+		return nil, fmt.Errorf("paillier: slot width %d must be positive", slotBits)
+	}
+	m := new(big.Int)
+	for i := len(values) - 1; i >= 0; i-- {
+		v := values[i]
+		if v == nil || v.Sign() < 0 || v.BitLen() > slotBits {
+			return nil, fmt.Errorf("%w: value %d exceeds %d bits", ErrSlotOverflow, i, slotBits)
+		}
+		m.Lsh(m, uint(slotBits))
+		m.Add(m, v)
+	}
+	return m, nil
+}
+
+// UnpackPlaintext 从明文 m 中取出低位的 n 个 slotBits 位槽，是 PackPlaintext 的逆
+func UnpackPlaintext(m *big.Int, n, slotBits int) []*big.Int {
+	values := make([]*big.Int, n)
+	for i := 0; i < n; i++ {
+		values[i] = lowBits(new(big.Int).Rsh(m, uint(i*slotBits)), slotBits)
+	}
+	return values
+}
+
+// MaxPackedSums 返回 valueBits 位的值在 slotBits 位槽中最多能累加多少个而不溢出：
+// k 个小于 2^valueBits 的数之和小于 k·2^valueBits，k <= 2^{slotBits-valueBits} 时不超过槽宽
+func MaxPackedSums(valueBits, slotBits int) int {
+	headroom := slotBits - valueBits
+	if valueBits < 0 || headroom < 0 {
+		return 0
+	}
+	if headroom >= 62 {
+		return 1 << 62
+	}
+	return 1 << headroom
+}
+
+// EncryptPacked 打包并加密 values，要求打包后的位数 len(values)·slotBits 小于 N 的位数，
+// 使槽内的和不会在模 N 下回绕
+func (pub *PublicKey) EncryptPacked(random io.Reader, values []*big.Int, slotBits int) (*big.Int, error) {
+	if len(values)*slotBits >= pub.N.BitLen() {
+		return nil, fmt.Errorf("%w: %d slots of %d bits do not fit in %d-bit N",
+			ErrMessageTooLarge, len(values), slotBits, pub.N.BitLen())
+	}
+	m, err := PackPlaintext(values, slotBits)
+	if err != nil {
+		return nil, err
+	}
+	return pub.Encrypt(random, m)
+}
@@ -0,0 +1,133 @@
+package paillier
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestPackPlaintext(t *testing.T) {
+	t.Run("打包与拆包", func(t *testing.T) {
+		values := []*big.Int{big.NewInt(1), big.NewInt(0), big.NewInt(255), big.NewInt(17)}
+		m, err := PackPlaintext(values, 8)
+		if err != nil {
+			t.Fatalf("PackPlaintext 失败: %v", err)
+		}
+		if m.Cmp(big.NewInt(0x11ff0001)) != 0 {
+			t.Errorf("期望 0x11ff0001, 得到 %#x", m)
+		}
+		got := UnpackPlaintext(m, len(values), 8)
+		for i := range values {
+			if got[i].Cmp(values[i]) != 0 {
+				t.Errorf("槽 %d: 期望 %v, 得到 %v", i, values[i], got[i])
+			}
+		}
+	})
+
+	t.Run("值超出槽宽", func(t *testing.T) {
+		for _, bad := range []*big.Int{big.NewInt(256), big.NewInt(-1), nil} {
+			if _, err := PackPlaintext([]*big.Int{big.NewInt(1), bad}, 8); !errors.Is(err, ErrSlotOverflow) {
+				t.Errorf("值 %v: 应该返回 ErrSlotOverflow, 得到 %v", bad, err)
+			}
+		}
+		if _, err := PackPlaintext(nil, 0); err == nil {
+			t.Error("应该返回错误当槽宽为 0")
+		}
+	})
+
+	t.Run("MaxPackedSums", func(t *testing.T) {
+		if MaxPackedSums(8, 12) != 16 || MaxPackedSums(8, 8) != 1 || MaxPackedSums(9, 8) != 0 {
+			t.Error("MaxPackedSums 结果不对")
+		}
+	})
+}
+
+func TestPackedHomomorphicAdd(t *testing.T) {
+	priv := testKey(t)
+	pub := priv.Public()
+	const valueBits, slotBits, slots = 16, 20, 8
+
+	encrypt := func(values []*big.Int) *big.Int {
+		c, err := pub.EncryptPacked(rand.Reader, values, slotBits)
+		if err != nil {
+			t.Fatalf("EncryptPacked 失败: %v", err)
+		}
+		return c
+	}
+	randomValues := func() []*big.Int {
+		vs := make([]*big.Int, slots)
+		for i := range vs {
+			vs[i], _ = rand.Int(rand.Reader, big.NewInt(1<<valueBits))
+		}
+		return vs
+	}
+
+	t.Run("逐槽相加", func(t *testing.T) {
+		a, b := randomValues(), randomValues()
+		sum, err := pub.Add(encrypt(a), encrypt(b))
+		if err != nil {
+			t.Fatalf("同态加法失败: %v", err)
+		}
+		m, err := priv.Decrypt(sum)
+		if err != nil {
+			t.Fatalf("解密失败: %v", err)
+		}
+		got := UnpackPlaintext(m, slots, slotBits)
+		for i := range got {
+			want := new(big.Int).Add(a[i], b[i])
+			if got[i].Cmp(want) != 0 {
+				t.Errorf("槽 %d: 期望 %v, 得到 %v", i, want, got[i])
+			}
+		}
+	})
+
+	t.Run("余量用尽后溢出", func(t *testing.T) {
+		// 每个槽都放最大值 2^valueBits - 1，恰好 MaxPackedSums 个相加不溢出，多一个就进位
+		max := make([]*big.Int, slots)
+		for i := range max {
+			max[i] = big.NewInt(1<<valueBits - 1)
+		}
+		limit := MaxPackedSums(valueBits, slotBits)
+		acc := pub.NewAccumulator()
+		count := 0
+		for ; count < limit; count++ {
+			if err := acc.Add(encrypt(max)); err != nil {
+				t.Fatalf("累加失败: %v", err)
+			}
+		}
+		check := func() bool {
+			m, err := priv.Decrypt(acc.Sum())
+			if err != nil {
+				t.Fatalf("解密失败: %v", err)
+			}
+			want := new(big.Int).Mul(max[0], big.NewInt(int64(count)))
+			for _, v := range UnpackPlaintext(m, slots, slotBits) {
+				if v.Cmp(want) != 0 {
+					return false
+				}
+			}
+			return true
+		}
+		if !check() {
+			t.Fatalf("%d 次累加不应该溢出", limit)
+		}
+		if err := acc.Add(encrypt(max)); err != nil {
+			t.Fatalf("累加失败: %v", err)
+		}
+		count++
+		if check() {
+			t.Error("超过 MaxPackedSums 后应该能检测到槽溢出")
+		}
+	})
+
+	t.Run("槽数超出 N", func(t *testing.T) {
+		tooMany := make([]*big.Int, pub.N.BitLen()/slotBits+1)
+		for i := range tooMany {
+			tooMany[i] = big.NewInt(1)
+		}
+		if _, err := pub.EncryptPacked(rand.Reader, tooMany, slotBits); !errors.Is(err, ErrMessageTooLarge) {
+			t.Errorf("应该返回 ErrMessageTooLarge, 得到 %v", err)
+		}
+	})
+}
@@ -3,8 +3,10 @@ package paillier
 import (
 	"crypto/rand"
 	"errors"
+	"fmt"
 	"io"
 	"math/big"
+	"sync"
 	"tss-crypto/pkg/mod"
 	"tss-crypto/pkg/prime"
 )
@@ -12,12 +14,20 @@ import (
 // 最小推荐模数位数
 const MinModulusBits = 2048
 
+// 可用 errors.Is 判断的错误类型
 var (
-	errMessageTooLarge   = errors.New("paillier: plaintext must satisfy 0 <= m < N")
-	errCiphertextInvalid = errors.New("paillier: ciphertext invalid")
-	errRandomnessInvalid = errors.New("paillier: randomness must satisfy gcd(r, N) = 1 and 1 <= r < N")
+	ErrModulusTooSmall   = errors.New("paillier: modulus too small")
+	ErrMessageTooLarge   = errors.New("paillier: plaintext must satisfy 0 <= m < N")
+	ErrCiphertextInvalid = errors.New("paillier: ciphertext invalid")
+	ErrRandomnessInvalid = errors.New("paillier: randomness must satisfy gcd(r, N) = 1 and 1 <= r < N")
+	ErrKeyDestroyed      = errors.New("paillier: private key has been destroyed")
+	ErrKeyMismatch       = errors.New("paillier: ciphertext belongs to a different public key")
+	ErrModulusInvalid    = errors.New("paillier: invalid modulus")
+	ErrNotRecoverable    = errors.New("paillier: key does not support randomness recovery")
+	ErrGeneratorInvalid  = errors.New("paillier: generator must be a unit mod N^2 whose order is a multiple of N")
 
 	bigOne = big.NewInt(1)
+	bigTwo = big.NewInt(2)
 )
 
 // 公钥结构，Paillier 公钥（N, G = N+1）
@@ -34,20 +44,75 @@ type PrivateKey struct {
 	PhiN   *big.Int // (p-1)*(q-1)
 	P      *big.Int
 	Q      *big.Int
+
+	// mu = L(g^lambda)^{-1} mod N，首次解密时惰性计算并缓存
+	muOnce sync.Once
+	mu     *big.Int
+	muErr  error
+
+	// muPhi = L(g^phi)^{-1} mod N，供 DecryptWithPhi 使用
+	muPhiOnce sync.Once
+	muPhi     *big.Int
+	muPhiErr  error
+
+	// NewDecryptor 创建且尚未销毁的解密器，它们持有 p、q 的副本，Destroy 时一并清除
+	decryptorsMu sync.Mutex
+	decryptors   []*Decryptor
 }
 
 // -----------------------------------------------------------------------------
 // 密钥生成
 // -----------------------------------------------------------------------------
 
+// KeyOptions 密钥生成选项
+type KeyOptions struct {
+	// 模数位数下限，0 表示使用 MinModulusBits。
+	// 警告：低于 2048 位的密钥不安全，只应在测试/预发环境中使用；
+	// 高安全场景可以设为 3072 或更高来强制更大的密钥。
+	MinBits int
+
+	// 是否使用安全素数 p,q
+	SafePrime bool
+}
+
 // GenerateKey 使用普通素数生成 Paillier 密钥
 func GenerateKey(random io.Reader, bits int) (*PrivateKey, error) {
-	return generateKey(random, bits, false)
+	return GenerateKeyWithOptions(random, bits, nil)
 }
 
 // GenerateKeySafePrime 生成 p,q 都为安全素数的 Paillier 密钥
 func GenerateKeySafePrime(random io.Reader, bits int) (*PrivateKey, error) {
-	return generateKey(random, bits, true)
+	return GenerateKeyWithOptions(random, bits, &KeyOptions{SafePrime: true})
+}
+
+// GenerateKeyWithOptions 按 opts 生成 Paillier 密钥，opts 为 nil 时等同于 GenerateKey
+func GenerateKeyWithOptions(random io.Reader, bits int, opts *KeyOptions) (*PrivateKey, error) {
+	minBits := MinModulusBits
+	safe := false
+	if opts != nil {
+		if opts.MinBits > 0 {
+			minBits = opts.MinBits
+		}
+		safe = opts.SafePrime
+	}
+	if bits < minBits {
+		return nil, fmt.Errorf("%w (min %d bits)", ErrModulusTooSmall, minBits)
+	}
+	return generateKey(random, bits, safe)
+}
+
+// insecureMinBits 是 GenerateKeyInsecure 允许的最小模数位数，防止过小的 bits 导致素数生成退化
+const insecureMinBits = 256
+
+// GenerateKeyInsecure 生成低于 MinModulusBits 的 Paillier 密钥，仅供测试使用。
+//
+// 警告：这样的密钥不安全（512 位模数可在数小时内分解），绝不能用于生产。
+// 它只是为了让测试套件在小密钥下快速运行；GenerateKey 仍强制 MinModulusBits 下限
+func GenerateKeyInsecure(random io.Reader, bits int) (*PrivateKey, error) {
+	if bits < insecureMinBits {
+		return nil, fmt.Errorf("%w (min %d bits even for insecure keys)", ErrModulusTooSmall, insecureMinBits)
+	}
+	return generateKey(random, bits, false)
 }
 
 // 获取公钥
@@ -59,40 +124,126 @@ func (priv *PrivateKey) Public() *PublicKey {
 	}
 }
 
-func generateKey(random io.Reader, bits int, safe bool) (*PrivateKey, error) {
-	if bits < MinModulusBits {
-		return nil, errors.New("paillier: modulus too small (min 2048 bits)")
+// Validate 检查从外部导入的公钥：N2 = N^2、G = N+1，且 N 没有小素因子。
+// 带小因子的 N 说明密钥格式错误或被恶意构造（N 的分解可被轻易恢复）
+func (pub *PublicKey) Validate() error {
+	if pub == nil || pub.N == nil || pub.N2 == nil || pub.G == nil {
+		return fmt.Errorf("%w: missing field", ErrModulusInvalid)
+	}
+	if pub.N.Cmp(bigOne) <= 0 {
+		return fmt.Errorf("%w: N must be greater than 1", ErrModulusInvalid)
+	}
+	if hasSmallFactor(pub.N) {
+		return fmt.Errorf("%w: N has a small prime factor", ErrModulusInvalid)
 	}
+	if pub.N2.Cmp(new(big.Int).Mul(pub.N, pub.N)) != 0 {
+		return fmt.Errorf("%w: N2 != N^2", ErrModulusInvalid)
+	}
+	if pub.G.Cmp(new(big.Int).Add(pub.N, bigOne)) != 0 {
+		return fmt.Errorf("%w: G != N+1", ErrModulusInvalid)
+	}
+	return nil
+}
 
-	half := bits / 2
+// NewPublicKey 仅由模数 N 重建公钥（例如 N 来自证书），派生 N2 = N^2、G = N+1。
+// N 必须为奇数、不少于 MinModulusBits 位且没有小素因子
+func NewPublicKey(N *big.Int) (*PublicKey, error) {
+	if N == nil || N.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: N must be positive", ErrModulusInvalid)
+	}
+	if N.Bit(0) == 0 {
+		return nil, fmt.Errorf("%w: N must be odd", ErrModulusInvalid)
+	}
+	if N.BitLen() < MinModulusBits {
+		return nil, fmt.Errorf("%w (min %d bits)", ErrModulusTooSmall, MinModulusBits)
+	}
+	pub := &PublicKey{
+		N:  new(big.Int).Set(N),
+		N2: new(big.Int).Mul(N, N),
+		G:  new(big.Int).Add(N, bigOne),
+	}
+	if err := pub.Validate(); err != nil {
+		return nil, err
+	}
+	return pub, nil
+}
 
-	var p, q *big.Int
-	var err error
+// CiphertextLen 返回 N^2 的字节长度，即定长编码下每个密文占用的字节数
+func (pub *PublicKey) CiphertextLen() int {
+	return (pub.N2.BitLen() + 7) / 8
+}
+
+// PlaintextLen 返回 N 的字节长度，即定长编码下每个明文占用的字节数
+func (pub *PublicKey) PlaintextLen() int {
+	return (pub.N.BitLen() + 7) / 8
+}
+
+// keygenMaxAttempts 是 generateKey 自检失败后重新生成的次数上限。
+// 正常的随机源下自检几乎不可能失败，上限只是为了让损坏的随机源报错而不是无限循环
+const keygenMaxAttempts = 8
+
+// selfCheckPlaintext 是密钥生成后自检加解密所用的固定明文
+var selfCheckPlaintext = big.NewInt(0x5eed)
+
+// generateKey 生成 p、q 并组装私钥，随后做自检：
+// 确认 L(g^lambda) 模 N 可逆，并用固定明文做一次加解密往返，失败则整体重新生成
+func generateKey(random io.Reader, bits int, safe bool) (*PrivateKey, error) {
+	for attempt := 0; attempt < keygenMaxAttempts; attempt++ {
+		p, q, N, err := generatePrimes(random, bits, safe)
+		if err != nil {
+			return nil, err
+		}
+		priv := newPrivateKey(p, q, N)
+		if priv.selfCheck() == nil {
+			return priv, nil
+		}
+	}
+	return nil, errors.New("paillier: key self-check failed repeatedly")
+}
+
+// generatePrimes 生成 N 恰好为 bits 位的 p、q：p 取 bits-bits/2 位、q 取 bits/2 位，
+// 两者最高位都已置位，乘积为 bits-1 或 bits 位，位数不足时整体重新生成。
+// 定长序列化（CiphertextLen/PlaintextLen）依赖这一点
+func generatePrimes(random io.Reader, bits int, safe bool) (p, q, N *big.Int, err error) {
+	qBits := bits / 2
+	pBits := bits - qBits
 
 	for {
 		if safe {
-			safePrime, err := prime.GenerateSafePrime(half, prime.DefaultConfig(), random)
+			// p、q 取两个独立的安全素数（不能用同一个安全素数的 P 和 (P-1)/2，
+			// 否则 gcd(N, phi(N)) != 1，密钥无法解密）
+			sp, err := prime.GenerateSafePrime(pBits, prime.DefaultConfig(), random)
 			if err != nil {
-				return nil, err
+				return nil, nil, nil, err
 			}
-			p, q = safePrime.P, safePrime.Q
+			sq, err := prime.GenerateSafePrime(qBits, prime.DefaultConfig(), random)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			p, q = sp.P, sq.P
 		} else {
-			p, err = rand.Prime(random, half)
+			p, err = rand.Prime(random, pBits)
 			if err != nil {
-				return nil, err
+				return nil, nil, nil, err
 			}
-			q, err = rand.Prime(random, half)
+			q, err = rand.Prime(random, qBits)
 			if err != nil {
-				return nil, err
+				return nil, nil, nil, err
 			}
 		}
 
-		if p.Cmp(q) != 0 {
-			break
+		if p.Cmp(q) == 0 {
+			continue
+		}
+		N = new(big.Int).Mul(p, q)
+		if N.BitLen() == bits {
+			return p, q, N, nil
 		}
 	}
+}
 
-	N := new(big.Int).Mul(p, q)
+// newPrivateKey 由素数 p、q 及 N = p·q 组装私钥，G 取 N+1
+func newPrivateKey(p, q, N *big.Int) *PrivateKey {
 	N2 := new(big.Int).Mul(N, N)
 	G := new(big.Int).Add(N, bigOne)
 
@@ -111,7 +262,28 @@ func generateKey(random io.Reader, bits int, safe bool) (*PrivateKey, error) {
 		PhiN:      phiN,
 		P:         p,
 		Q:         q,
-	}, nil
+	}
+}
+
+// selfCheck 确认 mu = L(g^lambda)^{-1} mod N 存在（顺带缓存），
+// 再以固定随机数 r = 2（N 为奇数，必与 N 互素）加密 selfCheckPlaintext 并解密比对。
+// 使用固定 r 是为了不额外消耗调用方随机源的输出
+func (priv *PrivateKey) selfCheck() error {
+	if _, err := priv.precomputeMu(); err != nil {
+		return err
+	}
+	c, err := priv.EncryptWithRandomness(selfCheckPlaintext, bigTwo)
+	if err != nil {
+		return err
+	}
+	m, err := priv.Decrypt(c)
+	if err != nil {
+		return err
+	}
+	if !mod.ConstantTimeEq(m, selfCheckPlaintext) {
+		return errors.New("paillier: self-check decryption mismatch")
+	}
+	return nil
 }
 
 // -----------------------------------------------------------------------------
@@ -120,30 +292,57 @@ func generateKey(random io.Reader, bits int, safe bool) (*PrivateKey, error) {
 
 // Encrypt 用 Paillier 公钥加密 m，使用随机 r ∈ Z*_N
 func (pub *PublicKey) Encrypt(random io.Reader, m *big.Int) (*big.Int, error) {
-	r, err := randomRelativelyPrime(random, pub.N)
+	r, err := randomUnit(random, pub.N)
 	if err != nil {
 		return nil, err
 	}
 	return pub.EncryptWithRandomness(m, r)
 }
 
+// EncryptZero 加密 0：g^0 = 1，只需计算 r^N mod N^2。
+// 结果可作为同态加法的单位元，或与其他密文相乘做重新随机化
+func (pub *PublicKey) EncryptZero(random io.Reader) (*big.Int, error) {
+	r, err := randomUnit(random, pub.N)
+	if err != nil {
+		return nil, err
+	}
+	return mod.ModExp(r, pub.N, pub.N2)
+}
+
+// EncryptReturningR 与 Encrypt 相同，但同时返回所用的随机数 r，
+// 供之后需要证明或打开密文的协议使用，省去一次 RecoverRandomness
+func (pub *PublicKey) EncryptReturningR(random io.Reader, m *big.Int) (c, r *big.Int, err error) {
+	r, err = randomUnit(random, pub.N)
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err = pub.EncryptWithRandomness(m, r)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, r, nil
+}
+
 // EncryptWithRandomness 用外部指定随机数 r 加密 m
 func (pub *PublicKey) EncryptWithRandomness(m, r *big.Int) (*big.Int, error) {
 	if m.Sign() < 0 || m.Cmp(pub.N) >= 0 {
-		return nil, errMessageTooLarge
-	}
-	if r.Sign() <= 0 || r.Cmp(pub.N) >= 0 {
-		return nil, errRandomnessInvalid
+		return nil, ErrMessageTooLarge
 	}
-	if new(big.Int).GCD(nil, nil, r, pub.N).Cmp(bigOne) != 0 {
-		return nil, errRandomnessInvalid
+	if !mod.IsUnit(r, pub.N) {
+		return nil, ErrRandomnessInvalid
 	}
 
 	// c = g^m * r^N mod N^2
 	// 计算 g^m mod N^2
-	gm := mod.ModExp(pub.G, m, pub.N2)
+	gm, err := mod.ModExp(pub.G, m, pub.N2)
+	if err != nil {
+		return nil, err
+	}
 	// 计算 r^N mod N^2
-	rN := mod.ModExp(r, pub.N, pub.N2)
+	rN, err := mod.ModExp(r, pub.N, pub.N2)
+	if err != nil {
+		return nil, err
+	}
 	// 计算 (g^m * r^N) mod N^2
 	c := mod.ModMul(gm, rN, pub.N2)
 	return c, nil
@@ -151,48 +350,129 @@ func (pub *PublicKey) EncryptWithRandomness(m, r *big.Int) (*big.Int, error) {
 
 // Decrypt 解密密文 c，返回明文 m
 func (priv *PrivateKey) Decrypt(c *big.Int) (*big.Int, error) {
-	if c.Sign() <= 0 || c.Cmp(priv.N2) >= 0 {
-		return nil, errCiphertextInvalid
+	if priv.destroyed() {
+		return nil, ErrKeyDestroyed
+	}
+	// 取缓存的 mu = L(g^lambda)^{-1} mod N
+	mu, err := priv.precomputeMu()
+	if err != nil {
+		return nil, err
+	}
+	return priv.decrypt(c, priv.Lambda, mu)
+}
+
+// DecryptWithPhi 使用 phi(N) 代替 lambda 作为解密指数，返回明文 m
+// 对应 mu = L(g^phi)^{-1} mod N，与 Decrypt 对合法密文给出相同结果
+func (priv *PrivateKey) DecryptWithPhi(c *big.Int) (*big.Int, error) {
+	if priv.destroyed() {
+		return nil, ErrKeyDestroyed
+	}
+	muPhi, err := priv.precomputeMuPhi()
+	if err != nil {
+		return nil, err
+	}
+	return priv.decrypt(c, priv.PhiN, muPhi)
+}
+
+// DecryptWithGenerator 解密以任意生成元 g（而非 N+1）加密的密文，用于与随机选取 g 的实现互通：
+// m = L(c^lambda mod N^2) · L(g^lambda mod N^2)^{-1} mod N。
+// g 必须属于 Z*_{N^2} 且阶是 N 的倍数（即 L(g^lambda) 模 N 可逆），否则返回 ErrGeneratorInvalid。
+// 每次调用都重新计算 g 对应的 mu；g = N+1 时应使用 Decrypt
+func (priv *PrivateKey) DecryptWithGenerator(c, g *big.Int) (*big.Int, error) {
+	if priv.destroyed() {
+		return nil, ErrKeyDestroyed
+	}
+	if !priv.IsValidCiphertext(g) {
+		return nil, fmt.Errorf("%w: g is not in Z*_{N^2}", ErrGeneratorInvalid)
 	}
+	mu, err := priv.computeMuFor(g, priv.Lambda)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGeneratorInvalid, err)
+	}
+	return priv.decrypt(c, priv.Lambda, mu)
+}
 
-	if new(big.Int).GCD(nil, nil, c, priv.N2).Cmp(bigOne) != 0 {
-		return nil, errCiphertextInvalid
+// decrypt 计算 m = L(c^exp mod N^2) * mu mod N
+func (priv *PrivateKey) decrypt(c, exp, mu *big.Int) (*big.Int, error) {
+	if !priv.IsValidCiphertext(c) {
+		return nil, ErrCiphertextInvalid
 	}
 
-	// 计算 c^lambda mod N^2
-	u := mod.ModExp(c, priv.Lambda, priv.N2)
+	// 计算 c^exp mod N^2
+	u, err := mod.ModExp(c, exp, priv.N2)
+	if err != nil {
+		return nil, err
+	}
 	// L(u) = (u - 1) / N
 	Lc := L(u, priv.N)
 
-	// 计算 g^lambda mod N^2
-	ug := mod.ModExp(priv.G, priv.Lambda, priv.N2)
+	// 计算 m = (L(u) * mu) mod N
+	m := mod.ModMul(Lc, mu, priv.N)
+	return m, nil
+}
 
-	// L(g^lambda) = (g^lambda - 1) / N
+// precomputeMu 计算并缓存 mu = L(g^lambda)^{-1} mod N，并发安全
+func (priv *PrivateKey) precomputeMu() (*big.Int, error) {
+	priv.muOnce.Do(func() {
+		priv.mu, priv.muErr = priv.computeMu(priv.Lambda)
+	})
+	return priv.mu, priv.muErr
+}
+
+// precomputeMuPhi 计算并缓存 muPhi = L(g^phi)^{-1} mod N，并发安全
+func (priv *PrivateKey) precomputeMuPhi() (*big.Int, error) {
+	priv.muPhiOnce.Do(func() {
+		priv.muPhi, priv.muPhiErr = priv.computeMu(priv.PhiN)
+	})
+	return priv.muPhi, priv.muPhiErr
+}
+
+// computeMu 以公钥的 G 计算 L(G^exp mod N^2)^{-1} mod N
+func (priv *PrivateKey) computeMu(exp *big.Int) (*big.Int, error) {
+	return priv.computeMuFor(priv.G, exp)
+}
+
+// computeMuFor 计算 L(g^exp mod N^2)^{-1} mod N
+func (priv *PrivateKey) computeMuFor(g, exp *big.Int) (*big.Int, error) {
+	// 计算 g^exp mod N^2
+	ug, err := mod.ModExp(g, exp, priv.N2)
+	if err != nil {
+		return nil, err
+	}
+
+	// L(g^exp) = (g^exp - 1) / N
 	Lg := L(ug, priv.N)
 
-	// 计算 L(g^lambda) 的模逆元
+	// 计算 L(g^exp) 的模逆元
 	inv, err := mod.ModInverse(Lg, priv.N)
 	if err != nil {
-		return nil, errors.New("paillier: cannot invert L(g^lambda)")
+		return nil, errors.New("paillier: cannot invert L(g^exp) mod N")
 	}
-
-	// 计算 m = (L(u) * inv) mod N
-	m := mod.ModMul(Lc, inv, priv.N)
-	return m, nil
+	return inv, nil
 }
 
 // -----------------------------------------------------------------------------
 // 同态运算
 // -----------------------------------------------------------------------------
 
+// IsValidCiphertext 检查 1 <= c < N^2 且 gcd(c, N) = 1
+// 这只是合法密文的必要条件：不持有私钥时无法判断 c 是否确为某个明文的加密，
+// 但可以拒绝越界值和与 N 有公因子的值（后者会泄露 N 的分解）
+func (pub *PublicKey) IsValidCiphertext(c *big.Int) bool {
+	if c == nil || c.Sign() <= 0 || c.Cmp(pub.N2) >= 0 {
+		return false
+	}
+	return new(big.Int).GCD(nil, nil, c, pub.N).Cmp(bigOne) == 0
+}
+
 // Add 同态加法：返回 Enc(m1 + m2)
 // 对两个密文执行同态加法运算，结果对应于明文的加法
 func (pub *PublicKey) Add(c1, c2 *big.Int) (*big.Int, error) {
-	if c1.Sign() <= 0 || c1.Cmp(pub.N2) >= 0 {
-		return nil, errCiphertextInvalid
+	if !pub.IsValidCiphertext(c1) {
+		return nil, ErrCiphertextInvalid
 	}
-	if c2.Sign() <= 0 || c2.Cmp(pub.N2) >= 0 {
-		return nil, errCiphertextInvalid
+	if !pub.IsValidCiphertext(c2) {
+		return nil, ErrCiphertextInvalid
 	}
 
 	// 同态加法：Enc(m1) * Enc(m2) = Enc(m1 + m2)
@@ -203,15 +483,51 @@ func (pub *PublicKey) Add(c1, c2 *big.Int) (*big.Int, error) {
 // Mul 同态乘法：返回 Enc(k * m)
 // 对密文与明文标量执行同态乘法运算，结果对应于明文的标量乘法
 func (pub *PublicKey) Mul(c, k *big.Int) (*big.Int, error) {
-	if c.Sign() <= 0 || c.Cmp(pub.N2) >= 0 {
-		return nil, errCiphertextInvalid
+	if !pub.IsValidCiphertext(c) {
+		return nil, ErrCiphertextInvalid
 	}
 
 	// 同态乘法：Enc(m)^k = Enc(k * m)
 	// 计算 k mod N
 	kMod := mod.Mod(k, pub.N)
 	// 计算 c^k mod N^2
-	return mod.ModExp(c, kMod, pub.N2), nil
+	return mod.ModExp(c, kMod, pub.N2)
+}
+
+// AddConstant 同态加明文常数：返回 Enc(m + k)
+// 由于 g = N+1，g^k ≡ 1 + kN (mod N^2)，无需模幂；结果不重新随机化
+func (pub *PublicKey) AddConstant(c, k *big.Int) (*big.Int, error) {
+	if !pub.IsValidCiphertext(c) {
+		return nil, ErrCiphertextInvalid
+	}
+	// gk = 1 + (k mod N)·N
+	gk := mod.Mod(k, pub.N)
+	gk.Mul(gk, pub.N)
+	gk.Add(gk, bigOne)
+	return mod.ModMul(c, gk, pub.N2), nil
+}
+
+// SubConstant 同态减明文常数：返回 Enc(m - k mod N)
+// g^{-k} ≡ 1 + (-k mod N)·N (mod N^2)，即 AddConstant(c, -k)；k 可以为负或不在 [0, N) 内，
+// 按 mod N 解释。k > m 时结果按 mod N 回绕为 N - (k - m)；结果不重新随机化
+func (pub *PublicKey) SubConstant(c, k *big.Int) (*big.Int, error) {
+	return pub.AddConstant(c, new(big.Int).Neg(k))
+}
+
+// Sub 同态减法：返回 Enc(m1 - m2 mod N)
+// 计算 c1 * c2^{-1} mod N^2
+func (pub *PublicKey) Sub(c1, c2 *big.Int) (*big.Int, error) {
+	if !pub.IsValidCiphertext(c1) {
+		return nil, ErrCiphertextInvalid
+	}
+	if !pub.IsValidCiphertext(c2) {
+		return nil, ErrCiphertextInvalid
+	}
+	inv, err := mod.ModInverse(c2, pub.N2)
+	if err != nil {
+		return nil, err
+	}
+	return mod.ModMul(c1, inv, pub.N2), nil
 }
 
 // -----------------------------------------------------------------------------
@@ -220,6 +536,12 @@ func (pub *PublicKey) Mul(c, k *big.Int) (*big.Int, error) {
 
 // RecoverRandomness 恢复随机数 r，根据 c 和 m 满足 c = g^m * r^N mod N^2
 func (priv *PrivateKey) RecoverRandomness(c, m *big.Int) (*big.Int, error) {
+	if priv.destroyed() {
+		return nil, ErrKeyDestroyed
+	}
+	if err := priv.checkRecoverable(); err != nil {
+		return nil, err
+	}
 	// C' = C * (1 - mN) mod N^2
 	N2 := priv.N2
 
@@ -232,21 +554,80 @@ func (priv *PrivateKey) RecoverRandomness(c, m *big.Int) (*big.Int, error) {
 	// 计算 (C * (1 - mN)) mod N^2
 	cDash := mod.ModMul(c, oneMinus, N2)
 
-	// 计算 N^{-1} mod phi(N)
+	// 计算 N^{-1} mod phi(N)，checkRecoverable 已保证其存在
 	M, err := mod.ModInverse(priv.N, priv.PhiN)
 	if err != nil {
-		return nil, errors.New("paillier: N^{-1} mod phi(N) undefined")
+		return nil, ErrNotRecoverable
 	}
 
 	// 计算 r = C'^M mod N
-	r := mod.ModExp(cDash, M, priv.N)
-	return r, nil
+	return mod.ModExp(cDash, M, priv.N)
+}
+
+// checkRecoverable 检查 gcd(N, phi(N)) = 1，即 N^{-1} mod phi(N) 存在。
+// 正常的 RSA 模数总是满足；畸形密钥（如 p | q-1）不满足时无法恢复随机数
+func (priv *PrivateKey) checkRecoverable() error {
+	if new(big.Int).GCD(nil, nil, priv.N, priv.PhiN).Cmp(bigOne) != 0 {
+		return ErrNotRecoverable
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// 密钥销毁
+// -----------------------------------------------------------------------------
+
+// Destroy 清除私钥中的秘密材料（Lambda、PhiN、P、Q 以及缓存的 mu），
+// 并销毁由 NewDecryptor 创建的所有解密器（其中的 p、q 副本与 CRT 参数）。
+// 先把 big.Int 底层的字数组逐字清零，再把字段置为 nil
+// 调用后 Decrypt 等操作返回错误；调用方需保证此时没有并发使用该私钥
+func (priv *PrivateKey) Destroy() {
+	priv.decryptorsMu.Lock()
+	decryptors := priv.decryptors
+	priv.decryptors = nil
+	priv.decryptorsMu.Unlock()
+	for _, d := range decryptors {
+		d.wipe()
+	}
+
+	for _, x := range []*big.Int{priv.Lambda, priv.PhiN, priv.P, priv.Q, priv.mu, priv.muPhi} {
+		zeroInt(x)
+	}
+	priv.Lambda = nil
+	priv.PhiN = nil
+	priv.P = nil
+	priv.Q = nil
+	priv.mu = nil
+	priv.muPhi = nil
+}
+
+// destroyed 判断私钥是否已被销毁
+func (priv *PrivateKey) destroyed() bool {
+	return priv.Lambda == nil || priv.PhiN == nil
+}
+
+// zeroInt 将 x 底层的字数组清零
+func zeroInt(x *big.Int) {
+	if x == nil {
+		return
+	}
+	words := x.Bits()
+	for i := range words {
+		words[i] = 0
+	}
+	x.SetInt64(0)
 }
 
 // -----------------------------------------------------------------------------
 // 工具函数
 // -----------------------------------------------------------------------------
 
+// hasSmallFactor 用 pkg/prime 的小素数表（1000 以内）对 N 试除
+func hasSmallFactor(N *big.Int) bool {
+	_, found := prime.SmallFactor(N)
+	return found
+}
+
 // L 计算 L(u) = (u - 1) / N
 func L(u, N *big.Int) *big.Int {
 	t := new(big.Int).Sub(u, bigOne)
@@ -260,12 +641,25 @@ func randomRelativelyPrime(random io.Reader, N *big.Int) (*big.Int, error) {
 		if err != nil {
 			return nil, err
 		}
-		if r.Sign() == 0 {
-			continue
-		}
-		g := new(big.Int).GCD(nil, nil, r, N)
-		if g.Cmp(bigOne) == 0 {
+		if mod.IsUnit(r, N) {
 			return r, nil
 		}
 	}
 }
+
+// randomUnit 为 Paillier 模数 N = p·q 采样 Z*_N 中的随机元素。
+// 直接在 [1, N) 中均匀取一次样（不丢弃 0，也就没有拒绝带来的偏差）；
+// 与 N 不互素的元素共 p+q-1 个，命中概率 (p+q-1)/(N-1) ≈ 2^{-(bits/2-1)}，
+// 对 2048 位模数约 2^-1023。命中时退回 randomRelativelyPrime 重新采样，
+// 因此输出总与 N 互素，而正常路径只消耗一次采样的随机数
+func randomUnit(random io.Reader, N *big.Int) (*big.Int, error) {
+	r, err := rand.Int(random, new(big.Int).Sub(N, bigOne))
+	if err != nil {
+		return nil, err
+	}
+	r.Add(r, bigOne)
+	if mod.IsUnit(r, N) {
+		return r, nil
+	}
+	return randomRelativelyPrime(random, N)
+}
@@ -2,7 +2,10 @@ package paillier
 
 import (
 	"crypto/rand"
+	"errors"
+	"fmt"
 	"math/big"
+	"sync"
 	"testing"
 )
 
@@ -30,6 +33,18 @@ func verifyEncryptDecrypt(t *testing.T, priv *PrivateKey, m *big.Int) {
 	}
 }
 
+// encryptWithGenerator 以任意生成元 g 加密：c = g^m · r^N mod N^2
+func encryptWithGenerator(t *testing.T, pub *PublicKey, g, m *big.Int) *big.Int {
+	t.Helper()
+	r, err := randomUnit(rand.Reader, pub.N)
+	if err != nil {
+		t.Fatalf("采样随机数失败: %v", err)
+	}
+	gm := new(big.Int).Exp(g, m, pub.N2)
+	rN := new(big.Int).Exp(r, pub.N, pub.N2)
+	return gm.Mul(gm, rN).Mod(gm, pub.N2)
+}
+
 // ================= 密钥生成测试 =================
 
 func TestGenerateKey(t *testing.T) {
@@ -98,11 +113,162 @@ func TestGenerateKey(t *testing.T) {
 	})
 }
 
+func TestGenerateKeyInsecure(t *testing.T) {
+	t.Run("生成 512 位测试密钥", func(t *testing.T) {
+		priv, err := GenerateKeyInsecure(rand.Reader, 512)
+		if err != nil {
+			t.Fatalf("生成密钥失败: %v", err)
+		}
+		if priv.N.BitLen() != 512 {
+			t.Errorf("N 应该恰好为 512 位, 得到 %d", priv.N.BitLen())
+		}
+		verifyEncryptDecrypt(t, priv, big.NewInt(42))
+	})
+
+	t.Run("GenerateKey 仍强制下限", func(t *testing.T) {
+		if _, err := GenerateKey(rand.Reader, 512); !errors.Is(err, ErrModulusTooSmall) {
+			t.Errorf("应该返回 ErrModulusTooSmall, 得到 %v", err)
+		}
+	})
+
+	t.Run("过小的位数被拒绝", func(t *testing.T) {
+		if _, err := GenerateKeyInsecure(rand.Reader, 64); !errors.Is(err, ErrModulusTooSmall) {
+			t.Errorf("应该返回 ErrModulusTooSmall, 得到 %v", err)
+		}
+	})
+}
+
+func TestGenerateKeyExactBits(t *testing.T) {
+	// 位长约束与模数大小无关，用 512/1024 位密钥覆盖，避免生成 2048 位密钥的开销
+	t.Run("N 恰好为指定位数", func(t *testing.T) {
+		for _, bits := range []int{512, 1024} {
+			for i := 0; i < 16; i++ {
+				priv, err := GenerateKeyInsecure(rand.Reader, bits)
+				if err != nil {
+					t.Fatalf("生成密钥失败: %v", err)
+				}
+				if priv.N.BitLen() != bits {
+					t.Fatalf("第 %d 次: N 应该恰好为 %d 位, 得到 %d", i, bits, priv.N.BitLen())
+				}
+				if priv.PlaintextLen() != bits/8 || priv.CiphertextLen() != bits/4 {
+					t.Fatalf("第 %d 次: 定长编码长度错误 %d/%d", i, priv.PlaintextLen(), priv.CiphertextLen())
+				}
+			}
+		}
+	})
+
+	t.Run("奇数位数", func(t *testing.T) {
+		for _, bits := range []int{511, 513} {
+			for i := 0; i < 8; i++ {
+				priv, err := GenerateKeyInsecure(rand.Reader, bits)
+				if err != nil {
+					t.Fatalf("生成密钥失败: %v", err)
+				}
+				if priv.N.BitLen() != bits {
+					t.Fatalf("N 应该恰好为 %d 位, 得到 %d", bits, priv.N.BitLen())
+				}
+				if priv.P.Cmp(priv.Q) == 0 {
+					t.Fatal("p 和 q 不应该相等")
+				}
+			}
+		}
+	})
+
+	t.Run("安全素数密钥", func(t *testing.T) {
+		priv, err := GenerateKeyWithOptions(rand.Reader, 512, &KeyOptions{MinBits: 512, SafePrime: true})
+		if err != nil {
+			t.Fatalf("生成安全素数密钥失败: %v", err)
+		}
+		if priv.N.BitLen() != 512 {
+			t.Errorf("N 应该恰好为 512 位, 得到 %d", priv.N.BitLen())
+		}
+	})
+}
+
+func TestKeySelfCheck(t *testing.T) {
+	t.Run("生成的密钥都能往返固定明文", func(t *testing.T) {
+		plaintext := big.NewInt(123456789)
+		for i := 0; i < 32; i++ {
+			priv, err := GenerateKeyInsecure(rand.Reader, testKeyBits)
+			if err != nil {
+				t.Fatalf("生成密钥失败: %v", err)
+			}
+			c, err := priv.Encrypt(rand.Reader, plaintext)
+			if err != nil {
+				t.Fatalf("第 %d 个密钥加密失败: %v", i, err)
+			}
+			m, err := priv.Decrypt(c)
+			if err != nil {
+				t.Fatalf("第 %d 个密钥解密失败: %v", i, err)
+			}
+			if m.Cmp(plaintext) != 0 {
+				t.Fatalf("第 %d 个密钥解密结果错误: %v", i, m)
+			}
+		}
+	})
+
+	t.Run("自检拒绝错误的 lambda", func(t *testing.T) {
+		priv := testKey(t)
+		bad := newPrivateKey(priv.P, priv.Q, priv.N)
+		// lambda 被替换为不含 lcm(p-1, q-1) 的值，g^lambda 不再 ≡ 1 (mod N)
+		bad.Lambda = new(big.Int).Sub(bad.Lambda, bigOne)
+		if err := bad.selfCheck(); err == nil {
+			t.Error("应该返回错误当 lambda 不正确")
+		}
+	})
+
+	t.Run("自检接受正确的密钥", func(t *testing.T) {
+		priv := testKey(t)
+		if err := newPrivateKey(priv.P, priv.Q, priv.N).selfCheck(); err != nil {
+			t.Errorf("正确的密钥不应该自检失败: %v", err)
+		}
+	})
+}
+
+func TestGenerateKeyWithOptions(t *testing.T) {
+	t.Run("显式允许时生成 1024 位密钥", func(t *testing.T) {
+		priv, err := GenerateKeyWithOptions(rand.Reader, 1024, &KeyOptions{MinBits: 1024})
+		if err != nil {
+			t.Fatalf("生成密钥失败: %v", err)
+		}
+		verifyEncryptDecrypt(t, priv, big.NewInt(42))
+	})
+
+	t.Run("默认选项拒绝 1024 位密钥", func(t *testing.T) {
+		if _, err := GenerateKeyWithOptions(rand.Reader, 1024, nil); !errors.Is(err, ErrModulusTooSmall) {
+			t.Errorf("应该返回 ErrModulusTooSmall, 得到 %v", err)
+		}
+		if _, err := GenerateKeyWithOptions(rand.Reader, 1024, &KeyOptions{}); !errors.Is(err, ErrModulusTooSmall) {
+			t.Errorf("应该返回 ErrModulusTooSmall, 得到 %v", err)
+		}
+		if _, err := GenerateKey(rand.Reader, 1024); !errors.Is(err, ErrModulusTooSmall) {
+			t.Errorf("应该返回 ErrModulusTooSmall, 得到 %v", err)
+		}
+	})
+
+	t.Run("提高下限", func(t *testing.T) {
+		if _, err := GenerateKeyWithOptions(rand.Reader, 2048, &KeyOptions{MinBits: 3072}); !errors.Is(err, ErrModulusTooSmall) {
+			t.Errorf("应该返回 ErrModulusTooSmall, 得到 %v", err)
+		}
+	})
+
+	t.Run("安全素数选项", func(t *testing.T) {
+		priv, err := GenerateKeyWithOptions(rand.Reader, 1024, &KeyOptions{MinBits: 1024, SafePrime: true})
+		if err != nil {
+			t.Fatalf("生成密钥失败: %v", err)
+		}
+		for _, p := range []*big.Int{priv.P, priv.Q} {
+			half := new(big.Int).Rsh(p, 1)
+			if !half.ProbablyPrime(20) {
+				t.Error("p, q 应该是安全素数")
+			}
+		}
+		verifyEncryptDecrypt(t, priv, big.NewInt(42))
+	})
+}
+
 func TestPublicKey(t *testing.T) {
-	priv, err := GenerateKey(rand.Reader, 2048)
-	if err != nil {
-		t.Fatalf("生成密钥失败: %v", err)
-	}
+	priv := testKey(t)
 
 	pub := priv.Public()
 	if pub == nil {
@@ -121,14 +287,112 @@ func TestPublicKey(t *testing.T) {
 	}
 }
 
-// ================= 加密/解密测试 =================
+func TestPublicKeyValidate(t *testing.T) {
+	priv := testKey1024(t)
 
-func TestEncryptDecrypt(t *testing.T) {
-	priv, err := GenerateKey(rand.Reader, 2048)
+	t.Run("真实密钥通过", func(t *testing.T) {
+		if err := priv.Public().Validate(); err != nil {
+			t.Errorf("真实公钥应该通过验证, 得到 %v", err)
+		}
+	})
+
+	newPub := func(N *big.Int) *PublicKey {
+		return &PublicKey{
+			N:  N,
+			N2: new(big.Int).Mul(N, N),
+			G:  new(big.Int).Add(N, bigOne),
+		}
+	}
+
+	t.Run("N = 3 * 大素数被拒绝", func(t *testing.T) {
+		N := new(big.Int).Mul(big.NewInt(3), priv.P)
+		if !hasSmallFactor(N) {
+			t.Error("hasSmallFactor 应该发现因子 3")
+		}
+		if err := newPub(N).Validate(); !errors.Is(err, ErrModulusInvalid) {
+			t.Errorf("应该返回 ErrModulusInvalid, 得到 %v", err)
+		}
+	})
+
+	t.Run("字段不一致被拒绝", func(t *testing.T) {
+		pub := newPub(priv.N)
+		pub.G = big.NewInt(2)
+		if err := pub.Validate(); !errors.Is(err, ErrModulusInvalid) {
+			t.Errorf("G 错误时应该返回 ErrModulusInvalid, 得到 %v", err)
+		}
+		pub = newPub(priv.N)
+		pub.N2 = priv.N
+		if err := pub.Validate(); !errors.Is(err, ErrModulusInvalid) {
+			t.Errorf("N2 错误时应该返回 ErrModulusInvalid, 得到 %v", err)
+		}
+		if err := (&PublicKey{}).Validate(); !errors.Is(err, ErrModulusInvalid) {
+			t.Errorf("空公钥应该返回 ErrModulusInvalid, 得到 %v", err)
+		}
+	})
+}
+
+func TestNewPublicKey(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader, MinModulusBits)
 	if err != nil {
 		t.Fatalf("生成密钥失败: %v", err)
 	}
 
+	t.Run("由 N 重建的公钥可与私钥配合使用", func(t *testing.T) {
+		pub, err := NewPublicKey(priv.N)
+		if err != nil {
+			t.Fatalf("NewPublicKey 失败: %v", err)
+		}
+		if pub.N2.Cmp(priv.N2) != 0 || pub.G.Cmp(priv.G) != 0 {
+			t.Error("派生的 N2、G 应该与原公钥一致")
+		}
+		m := big.NewInt(20240601)
+		c, err := pub.Encrypt(rand.Reader, m)
+		if err != nil {
+			t.Fatalf("加密失败: %v", err)
+		}
+		got, err := priv.Decrypt(c)
+		if err != nil {
+			t.Fatalf("解密失败: %v", err)
+		}
+		if got.Cmp(m) != 0 {
+			t.Errorf("解密结果应该是 %v, 得到 %v", m, got)
+		}
+	})
+
+	t.Run("不修改传入的 N", func(t *testing.T) {
+		N := new(big.Int).Set(priv.N)
+		pub, _ := NewPublicKey(N)
+		N.SetInt64(1)
+		if pub.N.Cmp(priv.N) != 0 {
+			t.Error("公钥不应该与传入的 N 共享底层存储")
+		}
+	})
+
+	t.Run("非法 N 被拒绝", func(t *testing.T) {
+		if _, err := NewPublicKey(nil); !errors.Is(err, ErrModulusInvalid) {
+			t.Errorf("nil 应该返回 ErrModulusInvalid, 得到 %v", err)
+		}
+		even := new(big.Int).Add(priv.N, bigOne)
+		if _, err := NewPublicKey(even); !errors.Is(err, ErrModulusInvalid) {
+			t.Errorf("偶数 N 应该返回 ErrModulusInvalid, 得到 %v", err)
+		}
+		small := testKey1024(t).N
+		if _, err := NewPublicKey(small); !errors.Is(err, ErrModulusTooSmall) {
+			t.Errorf("1024 位 N 应该返回 ErrModulusTooSmall, 得到 %v", err)
+		}
+		// 3·N 仍是奇数且位数足够，只能靠小因子检查拒绝
+		withFactor := new(big.Int).Mul(big.NewInt(3), priv.N)
+		if _, err := NewPublicKey(withFactor); !errors.Is(err, ErrModulusInvalid) {
+			t.Errorf("含小因子的 N 应该返回 ErrModulusInvalid, 得到 %v", err)
+		}
+	})
+}
+
+// ================= 加密/解密测试 =================
+
+func TestEncryptDecrypt(t *testing.T) {
+	priv := testKey(t)
+
 	t.Run("加密解密小数字", func(t *testing.T) {
 		m := big.NewInt(42)
 		verifyEncryptDecrypt(t, priv, m)
@@ -181,10 +445,7 @@ func TestEncryptDecrypt(t *testing.T) {
 }
 
 func TestEncryptWithRandomness(t *testing.T) {
-	priv, err := GenerateKey(rand.Reader, 2048)
-	if err != nil {
-		t.Fatalf("生成密钥失败: %v", err)
-	}
+	priv := testKey(t)
 	pub := priv.Public()
 
 	t.Run("使用指定随机数加密", func(t *testing.T) {
@@ -250,11 +511,89 @@ func TestEncryptWithRandomness(t *testing.T) {
 	})
 }
 
+func TestEncryptZero(t *testing.T) {
+	priv := testKey(t)
+	pub := priv.Public()
+
+	t.Run("解密为 0 且互不相同", func(t *testing.T) {
+		seen := make(map[string]bool)
+		for i := 0; i < 10; i++ {
+			c, err := pub.EncryptZero(rand.Reader)
+			if err != nil {
+				t.Fatalf("EncryptZero 失败: %v", err)
+			}
+			if !pub.IsValidCiphertext(c) {
+				t.Fatal("EncryptZero 的结果应该是合法密文")
+			}
+			m, err := priv.Decrypt(c)
+			if err != nil {
+				t.Fatalf("解密失败: %v", err)
+			}
+			if m.Sign() != 0 {
+				t.Errorf("期望 0, 得到 %v", m)
+			}
+			if seen[c.String()] {
+				t.Error("两次 EncryptZero 不应该得到相同的密文")
+			}
+			seen[c.String()] = true
+		}
+	})
+
+	t.Run("重新随机化", func(t *testing.T) {
+		c, err := pub.Encrypt(rand.Reader, big.NewInt(99))
+		if err != nil {
+			t.Fatalf("加密失败: %v", err)
+		}
+		zero, err := pub.EncryptZero(rand.Reader)
+		if err != nil {
+			t.Fatalf("EncryptZero 失败: %v", err)
+		}
+		rerand, err := pub.Add(c, zero)
+		if err != nil {
+			t.Fatalf("同态加法失败: %v", err)
+		}
+		if rerand.Cmp(c) == 0 {
+			t.Error("重新随机化后的密文应该不同")
+		}
+		m, err := priv.Decrypt(rerand)
+		if err != nil || m.Int64() != 99 {
+			t.Errorf("期望 99, 得到 %v, %v", m, err)
+		}
+	})
+}
+
+func TestEncryptReturningR(t *testing.T) {
+	priv := testKey(t)
+	pub := priv.Public()
+
+	t.Run("返回的 r 可复现密文", func(t *testing.T) {
+		m := big.NewInt(9876)
+		c, r, err := pub.EncryptReturningR(rand.Reader, m)
+		if err != nil {
+			t.Fatalf("加密失败: %v", err)
+		}
+		c2, err := pub.EncryptWithRandomness(m, r)
+		if err != nil {
+			t.Fatalf("EncryptWithRandomness 失败: %v", err)
+		}
+		if c.Cmp(c2) != 0 {
+			t.Error("EncryptWithRandomness(m, r) 应该复现相同密文")
+		}
+		recovered, err := priv.RecoverRandomness(c, m)
+		if err != nil || recovered.Cmp(r) != 0 {
+			t.Errorf("RecoverRandomness 应该得到相同的 r, 得到 %v, %v", recovered, err)
+		}
+	})
+
+	t.Run("明文超出范围", func(t *testing.T) {
+		if _, _, err := pub.EncryptReturningR(rand.Reader, pub.N); !errors.Is(err, ErrMessageTooLarge) {
+			t.Errorf("应该返回 ErrMessageTooLarge, 得到 %v", err)
+		}
+	})
+}
+
 func TestDecryptInvalidCiphertext(t *testing.T) {
-	priv, err := GenerateKey(rand.Reader, 2048)
-	if err != nil {
-		t.Fatalf("生成密钥失败: %v", err)
-	}
+	priv := testKey(t)
 
 	t.Run("密文为零", func(t *testing.T) {
 		c := big.NewInt(0)
@@ -273,13 +612,209 @@ func TestDecryptInvalidCiphertext(t *testing.T) {
 	})
 }
 
-// ================= 同态运算测试 =================
+// decryptUncached 按未缓存 mu 的原始公式解密，作为对照
+func decryptUncached(priv *PrivateKey, c *big.Int) *big.Int {
+	u := new(big.Int).Exp(c, priv.Lambda, priv.N2)
+	ug := new(big.Int).Exp(priv.G, priv.Lambda, priv.N2)
+	inv := new(big.Int).ModInverse(L(ug, priv.N), priv.N)
+	m := new(big.Int).Mul(L(u, priv.N), inv)
+	return m.Mod(m, priv.N)
+}
 
-func TestHomomorphicAdd(t *testing.T) {
-	priv, err := GenerateKey(rand.Reader, 2048)
+func TestIsValidCiphertext(t *testing.T) {
+	priv := testKey1024(t)
+	pub := priv.Public()
+
+	t.Run("合法密文通过", func(t *testing.T) {
+		for _, m := range []int64{0, 1, 12345} {
+			c, _ := pub.Encrypt(rand.Reader, big.NewInt(m))
+			if !pub.IsValidCiphertext(c) {
+				t.Errorf("Enc(%d) 应该通过检查", m)
+			}
+		}
+	})
+
+	t.Run("非法值被拒绝", func(t *testing.T) {
+		cases := []struct {
+			name string
+			c    *big.Int
+		}{
+			{"nil", nil},
+			{"0", big.NewInt(0)},
+			{"负数", big.NewInt(-5)},
+			{"N^2", pub.N2},
+			{"与 N 共享因子 p", new(big.Int).Mul(priv.P, big.NewInt(3))},
+			{"与 N 共享因子 q", new(big.Int).Set(priv.Q)},
+		}
+		for _, tc := range cases {
+			if pub.IsValidCiphertext(tc.c) {
+				t.Errorf("%s: 应该被拒绝", tc.name)
+			}
+		}
+	})
+
+	t.Run("Add/Mul 使用相同的检查", func(t *testing.T) {
+		c, _ := pub.Encrypt(rand.Reader, big.NewInt(1))
+		bad := new(big.Int).Set(priv.P)
+		if _, err := pub.Add(c, bad); !errors.Is(err, ErrCiphertextInvalid) {
+			t.Errorf("Add 应该返回 ErrCiphertextInvalid, 得到 %v", err)
+		}
+		if _, err := pub.Mul(bad, big.NewInt(2)); !errors.Is(err, ErrCiphertextInvalid) {
+			t.Errorf("Mul 应该返回 ErrCiphertextInvalid, 得到 %v", err)
+		}
+	})
+}
+
+func TestDecryptCachedMu(t *testing.T) {
+	priv := testKey(t)
+	pub := priv.Public()
+
+	t.Run("缓存 mu 后结果与原始公式一致", func(t *testing.T) {
+		for i := 0; i < 5; i++ {
+			m, _ := rand.Int(rand.Reader, priv.N)
+			c, _ := pub.Encrypt(rand.Reader, m)
+
+			decrypted, err := priv.Decrypt(c)
+			if err != nil {
+				t.Fatalf("解密失败: %v", err)
+			}
+			if decrypted.Cmp(m) != 0 {
+				t.Errorf("解密结果不正确: 期望 %v, 得到 %v", m, decrypted)
+			}
+			if expected := decryptUncached(priv, c); decrypted.Cmp(expected) != 0 {
+				t.Errorf("与未缓存解密结果不一致: 期望 %v, 得到 %v", expected, decrypted)
+			}
+		}
+	})
+
+	t.Run("并发解密", func(t *testing.T) {
+		fresh, err := GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatalf("生成密钥失败: %v", err)
+		}
+		m := big.NewInt(777)
+		c, _ := fresh.Public().Encrypt(rand.Reader, m)
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 8)
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				decrypted, err := fresh.Decrypt(c)
+				if err != nil {
+					errs <- err
+					return
+				}
+				if decrypted.Cmp(m) != 0 {
+					errs <- fmt.Errorf("期望 %v, 得到 %v", m, decrypted)
+				}
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			t.Error(err)
+		}
+	})
+}
+
+func TestDecryptWithPhi(t *testing.T) {
+	priv := testKey(t)
+	pub := priv.Public()
+
+	t.Run("与 Decrypt 结果一致", func(t *testing.T) {
+		values := []*big.Int{
+			big.NewInt(0),
+			big.NewInt(42),
+			new(big.Int).Sub(priv.N, bigOne),
+		}
+		r, _ := rand.Int(rand.Reader, priv.N)
+		values = append(values, r)
+
+		for _, m := range values {
+			c, err := pub.Encrypt(rand.Reader, m)
+			if err != nil {
+				t.Fatalf("加密失败: %v", err)
+			}
+			d1, err := priv.Decrypt(c)
+			if err != nil {
+				t.Fatalf("Decrypt 失败: %v", err)
+			}
+			d2, err := priv.DecryptWithPhi(c)
+			if err != nil {
+				t.Fatalf("DecryptWithPhi 失败: %v", err)
+			}
+			if d1.Cmp(d2) != 0 || d2.Cmp(m) != 0 {
+				t.Errorf("解密结果不一致: 明文 %v, Decrypt %v, DecryptWithPhi %v", m, d1, d2)
+			}
+		}
+	})
+
+	t.Run("非法密文", func(t *testing.T) {
+		if _, err := priv.DecryptWithPhi(big.NewInt(0)); err == nil {
+			t.Error("应该返回错误当密文为零")
+		}
+	})
+}
+
+func TestDecryptWithGenerator(t *testing.T) {
+	priv := testKey(t)
+	pub := priv.Public()
+
+	// 随机生成元 g = (1+N)^a · b^N mod N^2，gcd(a, N) = 1 时其阶是 N 的倍数
+	a, err := randomUnit(rand.Reader, pub.N)
 	if err != nil {
-		t.Fatalf("生成密钥失败: %v", err)
+		t.Fatalf("采样失败: %v", err)
+	}
+	b, err := randomUnit(rand.Reader, pub.N)
+	if err != nil {
+		t.Fatalf("采样失败: %v", err)
 	}
+	g := new(big.Int).Exp(pub.G, a, pub.N2)
+	g.Mul(g, new(big.Int).Exp(b, pub.N, pub.N2)).Mod(g, pub.N2)
+
+	t.Run("自定义生成元往返", func(t *testing.T) {
+		r, _ := rand.Int(rand.Reader, priv.N)
+		for _, m := range []*big.Int{big.NewInt(0), big.NewInt(42), new(big.Int).Sub(priv.N, bigOne), r} {
+			c := encryptWithGenerator(t, pub, g, m)
+			got, err := priv.DecryptWithGenerator(c, g)
+			if err != nil {
+				t.Fatalf("DecryptWithGenerator 失败: %v", err)
+			}
+			if got.Cmp(m) != 0 {
+				t.Errorf("期望 %v, 得到 %v", m, got)
+			}
+		}
+	})
+
+	t.Run("g = N+1 与 Decrypt 一致", func(t *testing.T) {
+		c, err := pub.Encrypt(rand.Reader, big.NewInt(777))
+		if err != nil {
+			t.Fatalf("加密失败: %v", err)
+		}
+		got, err := priv.DecryptWithGenerator(c, pub.G)
+		if err != nil || got.Int64() != 777 {
+			t.Errorf("期望 777, 得到 %v, %v", got, err)
+		}
+	})
+
+	t.Run("非法生成元", func(t *testing.T) {
+		c := encryptWithGenerator(t, pub, g, big.NewInt(5))
+		// b^N 的阶与 N 互素，L(g^lambda) = 0 不可逆
+		nthResidue := new(big.Int).Exp(b, pub.N, pub.N2)
+		for _, bad := range []*big.Int{nil, big.NewInt(0), pub.N, pub.N2, nthResidue} {
+			if _, err := priv.DecryptWithGenerator(c, bad); !errors.Is(err, ErrGeneratorInvalid) {
+				t.Errorf("g = %v: 应该返回 ErrGeneratorInvalid, 得到 %v", bad, err)
+			}
+		}
+	})
+}
+
+// ================= 同态运算测试 =================
+
+func TestHomomorphicAdd(t *testing.T) {
+	priv := testKey(t)
 	pub := priv.Public()
 
 	t.Run("同态加法基本测试", func(t *testing.T) {
@@ -357,10 +892,7 @@ func TestHomomorphicAdd(t *testing.T) {
 }
 
 func TestHomomorphicMul(t *testing.T) {
-	priv, err := GenerateKey(rand.Reader, 2048)
-	if err != nil {
-		t.Fatalf("生成密钥失败: %v", err)
-	}
+	priv := testKey(t)
 	pub := priv.Public()
 
 	t.Run("同态乘法基本测试", func(t *testing.T) {
@@ -431,11 +963,102 @@ func TestHomomorphicMul(t *testing.T) {
 	})
 }
 
-func TestHomomorphicCombined(t *testing.T) {
-	priv, err := GenerateKey(rand.Reader, 2048)
-	if err != nil {
-		t.Fatalf("生成密钥失败: %v", err)
+func TestAddConstant(t *testing.T) {
+	priv := testKey1024(t)
+	pub := priv.Public()
+
+	cases := []struct {
+		name string
+		m, k *big.Int
+	}{
+		{"小常数", big.NewInt(10), big.NewInt(32)},
+		{"k = 0", big.NewInt(10), big.NewInt(0)},
+		{"负常数", big.NewInt(10), big.NewInt(-3)},
+		{"越过 N 回绕", new(big.Int).Sub(priv.N, big.NewInt(1)), big.NewInt(2)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, _ := pub.Encrypt(rand.Reader, tc.m)
+			cSum, err := pub.AddConstant(c, tc.k)
+			if err != nil {
+				t.Fatalf("AddConstant 失败: %v", err)
+			}
+			result, err := priv.Decrypt(cSum)
+			if err != nil {
+				t.Fatalf("解密失败: %v", err)
+			}
+			expected := new(big.Int).Add(tc.m, tc.k)
+			expected.Mod(expected, priv.N)
+			if result.Cmp(expected) != 0 {
+				t.Errorf("期望 %v, 得到 %v", expected, result)
+			}
+		})
 	}
+
+	t.Run("无效密文", func(t *testing.T) {
+		if _, err := pub.AddConstant(big.NewInt(0), big.NewInt(1)); !errors.Is(err, ErrCiphertextInvalid) {
+			t.Errorf("应该返回 ErrCiphertextInvalid, 得到 %v", err)
+		}
+	})
+}
+
+func TestSubConstant(t *testing.T) {
+	priv := testKey1024(t)
+	pub := priv.Public()
+
+	cases := []struct {
+		name string
+		m, k *big.Int
+	}{
+		{"小常数", big.NewInt(42), big.NewInt(10)},
+		{"k = 0", big.NewInt(42), big.NewInt(0)},
+		{"k = m", big.NewInt(42), big.NewInt(42)},
+		{"k > m 回绕", big.NewInt(10), big.NewInt(32)},
+		{"负常数", big.NewInt(10), big.NewInt(-3)},
+		{"k >= N", big.NewInt(10), new(big.Int).Add(priv.N, big.NewInt(4))},
+		{"k < -N", big.NewInt(10), new(big.Int).Sub(big.NewInt(-4), priv.N)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, _ := pub.Encrypt(rand.Reader, tc.m)
+			cDiff, err := pub.SubConstant(c, tc.k)
+			if err != nil {
+				t.Fatalf("SubConstant 失败: %v", err)
+			}
+			result, err := priv.Decrypt(cDiff)
+			if err != nil {
+				t.Fatalf("解密失败: %v", err)
+			}
+			expected := new(big.Int).Sub(tc.m, tc.k)
+			expected.Mod(expected, priv.N)
+			if result.Cmp(expected) != 0 {
+				t.Errorf("期望 %v, 得到 %v", expected, result)
+			}
+		})
+	}
+
+	t.Run("与 AddConstant 互逆", func(t *testing.T) {
+		m, k := big.NewInt(1000), big.NewInt(123456)
+		c, _ := pub.Encrypt(rand.Reader, m)
+		cAdd, _ := pub.AddConstant(c, k)
+		cBack, err := pub.SubConstant(cAdd, k)
+		if err != nil {
+			t.Fatalf("SubConstant 失败: %v", err)
+		}
+		if cBack.Cmp(c) != 0 {
+			t.Error("先加后减同一常数应该得到原密文")
+		}
+	})
+
+	t.Run("无效密文", func(t *testing.T) {
+		if _, err := pub.SubConstant(big.NewInt(0), big.NewInt(1)); !errors.Is(err, ErrCiphertextInvalid) {
+			t.Errorf("应该返回 ErrCiphertextInvalid, 得到 %v", err)
+		}
+	})
+}
+
+func TestHomomorphicCombined(t *testing.T) {
+	priv := testKey(t)
 	pub := priv.Public()
 
 	t.Run("组合运算: (m1 * k1) + (m2 * k2)", func(t *testing.T) {
@@ -473,10 +1096,7 @@ func TestHomomorphicCombined(t *testing.T) {
 // ================= 随机数恢复测试 =================
 
 func TestRecoverRandomness(t *testing.T) {
-	priv, err := GenerateKey(rand.Reader, 2048)
-	if err != nil {
-		t.Fatalf("生成密钥失败: %v", err)
-	}
+	priv := testKey(t)
 	pub := priv.Public()
 
 	t.Run("恢复随机数", func(t *testing.T) {
@@ -513,6 +1133,125 @@ func TestRecoverRandomness(t *testing.T) {
 			t.Error("使用相同的明文和随机数应该产生相同的密文")
 		}
 	})
+
+	t.Run("gcd(N, phi) != 1 的畸形密钥", func(t *testing.T) {
+		// N = 3·7，phi = 2·6 = 12，gcd(21, 12) = 3
+		N := big.NewInt(21)
+		bad := &PrivateKey{
+			PublicKey: PublicKey{N: N, N2: new(big.Int).Mul(N, N), G: big.NewInt(22)},
+			Lambda:    big.NewInt(6),
+			PhiN:      big.NewInt(12),
+			P:         big.NewInt(3),
+			Q:         big.NewInt(7),
+		}
+		if err := bad.checkRecoverable(); !errors.Is(err, ErrNotRecoverable) {
+			t.Errorf("checkRecoverable 应该返回 ErrNotRecoverable, 得到 %v", err)
+		}
+		if _, err := bad.RecoverRandomness(big.NewInt(5), big.NewInt(1)); !errors.Is(err, ErrNotRecoverable) {
+			t.Errorf("RecoverRandomness 应该返回 ErrNotRecoverable, 得到 %v", err)
+		}
+		if err := priv.checkRecoverable(); err != nil {
+			t.Errorf("正常密钥不应该报错: %v", err)
+		}
+	})
+}
+
+// ================= 密钥销毁测试 =================
+
+func TestDestroy(t *testing.T) {
+	priv := testKey(t)
+	pub := priv.Public()
+	c, _ := pub.Encrypt(rand.Reader, big.NewInt(42))
+
+	// 先解密一次，让 mu 被缓存
+	if _, err := priv.Decrypt(c); err != nil {
+		t.Fatalf("解密失败: %v", err)
+	}
+
+	// 保留底层字数组的引用，确认被清零
+	lambdaWords := priv.Lambda.Bits()
+	pWords := priv.P.Bits()
+
+	priv.Destroy()
+
+	t.Run("秘密字段被清除", func(t *testing.T) {
+		if priv.Lambda != nil || priv.PhiN != nil || priv.P != nil || priv.Q != nil {
+			t.Error("秘密字段应该被置为 nil")
+		}
+		if priv.mu != nil || priv.muPhi != nil {
+			t.Error("缓存的 mu 应该被置为 nil")
+		}
+		for _, words := range [][]big.Word{lambdaWords, pWords} {
+			for i, w := range words {
+				if w != 0 {
+					t.Fatalf("底层字 %d 未被清零", i)
+				}
+			}
+		}
+	})
+
+	t.Run("销毁后解密返回错误", func(t *testing.T) {
+		if _, err := priv.Decrypt(c); err == nil {
+			t.Error("销毁后 Decrypt 应该返回错误")
+		}
+		if _, err := priv.DecryptWithPhi(c); err == nil {
+			t.Error("销毁后 DecryptWithPhi 应该返回错误")
+		}
+		if _, err := priv.RecoverRandomness(c, big.NewInt(42)); err == nil {
+			t.Error("销毁后 RecoverRandomness 应该返回错误")
+		}
+	})
+
+	t.Run("公钥仍然可用", func(t *testing.T) {
+		if _, err := pub.Encrypt(rand.Reader, big.NewInt(1)); err != nil {
+			t.Errorf("销毁私钥后公钥加密不应该失败: %v", err)
+		}
+	})
+}
+
+// ================= 错误类型测试 =================
+
+func TestErrors(t *testing.T) {
+	priv := testKey(t)
+	pub := priv.Public()
+	c, _ := pub.Encrypt(rand.Reader, big.NewInt(1))
+	r, _ := randomRelativelyPrime(rand.Reader, pub.N)
+
+	_, errKeySize := GenerateKey(rand.Reader, 1024)
+	_, errSafeKeySize := GenerateKeySafePrime(rand.Reader, 1024)
+	_, errMsg := pub.EncryptWithRandomness(pub.N, r)
+	_, errNegMsg := pub.Encrypt(rand.Reader, big.NewInt(-1))
+	_, errRandZero := pub.EncryptWithRandomness(big.NewInt(1), big.NewInt(0))
+	_, errRandGCD := pub.EncryptWithRandomness(big.NewInt(1), priv.P)
+	_, errDecrypt := priv.Decrypt(big.NewInt(0))
+	_, errDecryptGCD := priv.Decrypt(priv.Q)
+	_, errAdd := pub.Add(c, pub.N2)
+	_, errMul := pub.Mul(big.NewInt(0), big.NewInt(2))
+
+	tests := []struct {
+		name   string
+		err    error
+		target error
+	}{
+		{"密钥位数太小", errKeySize, ErrModulusTooSmall},
+		{"安全素数密钥位数太小", errSafeKeySize, ErrModulusTooSmall},
+		{"明文 >= N", errMsg, ErrMessageTooLarge},
+		{"明文为负数", errNegMsg, ErrMessageTooLarge},
+		{"随机数为零", errRandZero, ErrRandomnessInvalid},
+		{"随机数与 N 不互质", errRandGCD, ErrRandomnessInvalid},
+		{"解密零密文", errDecrypt, ErrCiphertextInvalid},
+		{"解密与 N 不互质的密文", errDecryptGCD, ErrCiphertextInvalid},
+		{"同态加法密文越界", errAdd, ErrCiphertextInvalid},
+		{"同态乘法密文为零", errMul, ErrCiphertextInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, tt.target) {
+				t.Errorf("errors.Is(%v, %v) 应该为 true", tt.err, tt.target)
+			}
+		})
+	}
 }
 
 // ================= 工具函数测试 =================
@@ -578,6 +1317,49 @@ func TestRandomRelativelyPrime(t *testing.T) {
 	})
 }
 
+func TestRandomUnit(t *testing.T) {
+	// 小模数 N = 11·13 让回退路径被频繁触发
+	small := big.NewInt(11 * 13)
+	priv := testKey1024(t)
+
+	cases := []struct {
+		name string
+		N    *big.Int
+	}{
+		{"N = 143", small},
+		{"1024 位模数", priv.N},
+	}
+	for _, tc := range cases {
+		N := tc.N
+		t.Run(tc.name, func(t *testing.T) {
+			for i := 0; i < 2000; i++ {
+				r, err := randomUnit(rand.Reader, N)
+				if err != nil {
+					t.Fatalf("randomUnit 失败: %v", err)
+				}
+				if r.Sign() <= 0 || r.Cmp(N) >= 0 {
+					t.Fatalf("随机数应该在 [1, N) 范围内, 得到 %v", r)
+				}
+				if new(big.Int).GCD(nil, nil, r, N).Cmp(bigOne) != 0 {
+					t.Fatalf("随机数应该与 N 互质, 得到 %v", r)
+				}
+			}
+		})
+	}
+
+	t.Run("小模数下覆盖所有单位", func(t *testing.T) {
+		// phi(143) = 120，2000 次采样应覆盖绝大多数单位
+		seen := make(map[int64]bool)
+		for i := 0; i < 2000; i++ {
+			r, _ := randomUnit(rand.Reader, small)
+			seen[r.Int64()] = true
+		}
+		if len(seen) < 110 {
+			t.Errorf("采样分布过窄: 仅覆盖 %d/120 个单位", len(seen))
+		}
+	})
+}
+
 // ================= 性能测试 =================
 
 func BenchmarkGenerateKey(b *testing.B) {
@@ -618,6 +1400,18 @@ func BenchmarkDecrypt(b *testing.B) {
 	}
 }
 
+func BenchmarkDecryptUncached(b *testing.B) {
+	priv, _ := GenerateKey(rand.Reader, 2048)
+	pub := priv.Public()
+	m := big.NewInt(12345)
+	c, _ := pub.Encrypt(rand.Reader, m)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		decryptUncached(priv, c)
+	}
+}
+
 func BenchmarkHomomorphicAdd(b *testing.B) {
 	priv, _ := GenerateKey(rand.Reader, 2048)
 	pub := priv.Public()
@@ -647,4 +1441,3 @@ func BenchmarkHomomorphicMul(b *testing.B) {
 		}
 	}
 }
-
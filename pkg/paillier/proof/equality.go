@@ -0,0 +1,152 @@
+package proof
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+
+	"tss-crypto/pkg/mod"
+	"tss-crypto/pkg/paillier"
+	"tss-crypto/pkg/transcript"
+)
+
+// 挑战值位数。挑战必须小于 N 的最小素因子才能保证可靠性，256 位远小于 1024 位的因子
+const challengeBits = 256
+
+var (
+	errNotEqual     = errors.New("proof: ciphertexts do not encrypt the same plaintext under the given randomness")
+	errInvalidInput = errors.New("proof: invalid public key or ciphertext")
+
+	bigOne = big.NewInt(1)
+)
+
+// EqProof 证明两个密文加密了同一明文
+//
+// 若 c1 = g^m r1^N, c2 = g^m r2^N (mod N^2)，则 d = c1/c2 = (r1/r2)^N 是 N 次剩余。
+// 证明者对 d 的 N 次根 ρ = r1/r2 mod N 做 Σ 协议：
+//
+//	承诺：A = s^N mod N^2，s ∈ Z*_N 随机
+//	挑战：e = H(N, c1, c2, A)（Fiat-Shamir）
+//	响应：Z = s·ρ^e mod N
+//
+// 验证：Z^N ≡ A·d^e (mod N^2)
+type EqProof struct {
+	A *big.Int
+	Z *big.Int
+}
+
+// ProveCiphertextEquality 证明 c1、c2 加密了同一明文，r1、r2 是各自的加密随机数
+// 若 c1/c2 与 (r1/r2)^N 不符（即明文不同或随机数不对）则返回错误
+func ProveCiphertextEquality(pub *paillier.PublicKey, c1, c2, r1, r2 *big.Int) (*EqProof, error) {
+	d, err := ciphertextRatio(pub, c1, c2)
+	if err != nil {
+		return nil, err
+	}
+
+	// ρ = r1 * r2^{-1} mod N
+	r2Inv, err := mod.ModInverse(r2, pub.N)
+	if err != nil {
+		return nil, err
+	}
+	rho := mod.ModMul(r1, r2Inv, pub.N)
+
+	// 自检：ρ^N ≡ d (mod N^2)
+	rhoN, err := mod.ModExp(rho, pub.N, pub.N2)
+	if err != nil {
+		return nil, err
+	}
+	// ρ 由秘密随机数导出，用常数时间比较
+	if !mod.ConstantTimeEq(rhoN, d) {
+		return nil, errNotEqual
+	}
+
+	// 承诺 A = s^N mod N^2
+	s, err := randomUnit(pub.N)
+	if err != nil {
+		return nil, err
+	}
+	A, err := mod.ModExp(s, pub.N, pub.N2)
+	if err != nil {
+		return nil, err
+	}
+
+	// 挑战 e 与响应 Z = s·ρ^e mod N
+	e := equalityChallenge(pub, c1, c2, A)
+	rhoE, err := mod.ModExp(rho, e, pub.N)
+	if err != nil {
+		return nil, err
+	}
+	Z := mod.ModMul(s, rhoE, pub.N)
+
+	return &EqProof{A: A, Z: Z}, nil
+}
+
+// Verify 验证 c1、c2 加密了同一明文
+func (p *EqProof) Verify(pub *paillier.PublicKey, c1, c2 *big.Int) bool {
+	if p == nil || p.A == nil || p.Z == nil {
+		return false
+	}
+	d, err := ciphertextRatio(pub, c1, c2)
+	if err != nil {
+		return false
+	}
+
+	// A ∈ Z*_{N^2}，Z ∈ Z*_N
+	if !mod.IsUnit(p.A, pub.N2) || !mod.IsUnit(p.Z, pub.N) {
+		return false
+	}
+
+	e := equalityChallenge(pub, c1, c2, p.A)
+
+	// 检查 Z^N ≡ A·d^e (mod N^2)
+	lhs, err := mod.ModExp(p.Z, pub.N, pub.N2)
+	if err != nil {
+		return false
+	}
+	dE, err := mod.ModExp(d, e, pub.N2)
+	if err != nil {
+		return false
+	}
+	rhs := mod.ModMul(p.A, dE, pub.N2)
+	return mod.ConstantTimeEq(lhs, rhs)
+}
+
+// ---- 内部实现 ----
+
+// ciphertextRatio 校验输入并计算 d = c1 * c2^{-1} mod N^2
+func ciphertextRatio(pub *paillier.PublicKey, c1, c2 *big.Int) (*big.Int, error) {
+	if pub == nil || pub.N == nil || pub.N2 == nil || c1 == nil || c2 == nil {
+		return nil, errInvalidInput
+	}
+	if !mod.IsUnit(c1, pub.N2) || !mod.IsUnit(c2, pub.N2) {
+		return nil, errInvalidInput
+	}
+	c2Inv, err := mod.ModInverse(c2, pub.N2)
+	if err != nil {
+		return nil, err
+	}
+	return mod.ModMul(c1, c2Inv, pub.N2), nil
+}
+
+// equalityChallenge 通过 transcript 计算 Fiat-Shamir 挑战 e = H(N, c1, c2, A) mod 2^challengeBits
+func equalityChallenge(pub *paillier.PublicKey, c1, c2, A *big.Int) *big.Int {
+	tr := transcript.New("paillier-ciphertext-equality")
+	tr.AppendInt("N", pub.N)
+	tr.AppendInt("c1", c1)
+	tr.AppendInt("c2", c2)
+	tr.AppendInt("A", A)
+	return tr.Challenge("e", new(big.Int).Lsh(bigOne, challengeBits))
+}
+
+// randomUnit 在 Z*_N 中均匀采样
+func randomUnit(N *big.Int) (*big.Int, error) {
+	for {
+		r, err := rand.Int(rand.Reader, N)
+		if err != nil {
+			return nil, err
+		}
+		if mod.IsUnit(r, N) {
+			return r, nil
+		}
+	}
+}
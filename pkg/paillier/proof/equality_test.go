@@ -0,0 +1,94 @@
+package proof
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"tss-crypto/pkg/paillier"
+)
+
+// encryptWithR 用新的随机数加密 m，同时返回随机数
+func encryptWithR(t *testing.T, pub *paillier.PublicKey, m *big.Int) (*big.Int, *big.Int) {
+	r, err := randomUnit(pub.N)
+	if err != nil {
+		t.Fatalf("生成随机数失败: %v", err)
+	}
+	c, err := pub.EncryptWithRandomness(m, r)
+	if err != nil {
+		t.Fatalf("加密失败: %v", err)
+	}
+	return c, r
+}
+
+func TestCiphertextEquality(t *testing.T) {
+	priv, err := paillier.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+	pub := priv.Public()
+	m := big.NewInt(123456)
+
+	c1, r1 := encryptWithR(t, pub, m)
+	c2, r2 := encryptWithR(t, pub, m)
+
+	t.Run("相同明文的证明通过", func(t *testing.T) {
+		proof, err := ProveCiphertextEquality(pub, c1, c2, r1, r2)
+		if err != nil {
+			t.Fatalf("生成证明失败: %v", err)
+		}
+		if !proof.Verify(pub, c1, c2) {
+			t.Error("相同明文的证明应该验证通过")
+		}
+	})
+
+	t.Run("不同明文无法生成证明", func(t *testing.T) {
+		c3, r3 := encryptWithR(t, pub, big.NewInt(654321))
+		if _, err := ProveCiphertextEquality(pub, c1, c3, r1, r3); err == nil {
+			t.Error("不同明文应该返回错误")
+		}
+	})
+
+	t.Run("证明不能挪用到其他密文对", func(t *testing.T) {
+		proof, err := ProveCiphertextEquality(pub, c1, c2, r1, r2)
+		if err != nil {
+			t.Fatalf("生成证明失败: %v", err)
+		}
+		c3, _ := encryptWithR(t, pub, big.NewInt(654321))
+		if proof.Verify(pub, c1, c3) {
+			t.Error("不同明文的密文对不应该验证通过")
+		}
+		// 交换顺序后挑战改变
+		if proof.Verify(pub, c2, c1) {
+			t.Error("交换密文顺序后不应该验证通过")
+		}
+	})
+
+	t.Run("篡改的证明", func(t *testing.T) {
+		proof, _ := ProveCiphertextEquality(pub, c1, c2, r1, r2)
+		tampered := &EqProof{A: proof.A, Z: new(big.Int).Add(proof.Z, bigOne)}
+		if tampered.Verify(pub, c1, c2) {
+			t.Error("篡改 Z 后不应该验证通过")
+		}
+		if (&EqProof{A: proof.A}).Verify(pub, c1, c2) {
+			t.Error("缺少 Z 时不应该验证通过")
+		}
+	})
+
+	t.Run("重随机化后的密文", func(t *testing.T) {
+		// c1 · s^N 是 c1 的重随机化，随机数为 r1·s
+		s, _ := randomUnit(pub.N)
+		zero, _ := pub.EncryptWithRandomness(big.NewInt(0), s)
+		c1Re, _ := pub.Add(c1, zero)
+		r1Re := new(big.Int).Mul(r1, s)
+		r1Re.Mod(r1Re, pub.N)
+
+		proof, err := ProveCiphertextEquality(pub, c1, c1Re, r1, r1Re)
+		if err != nil {
+			t.Fatalf("生成证明失败: %v", err)
+		}
+		if !proof.Verify(pub, c1, c1Re) {
+			t.Error("重随机化后的密文应该验证通过")
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package paillier
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// -----------------------------------------------------------------------------
+// 向量运算
+// -----------------------------------------------------------------------------
+
+// EncryptVector 逐元素加密整数向量，出错时报告出错元素的下标
+func (pub *PublicKey) EncryptVector(random io.Reader, ms []*big.Int) ([]*big.Int, error) {
+	cs := make([]*big.Int, len(ms))
+	for i, m := range ms {
+		if m == nil {
+			return nil, fmt.Errorf("paillier: element %d: %w", i, ErrMessageTooLarge)
+		}
+		c, err := pub.Encrypt(random, m)
+		if err != nil {
+			return nil, fmt.Errorf("paillier: element %d: %w", i, err)
+		}
+		cs[i] = c
+	}
+	return cs, nil
+}
+
+// DecryptVector 逐元素解密密文向量，出错时报告出错元素的下标
+func (priv *PrivateKey) DecryptVector(cs []*big.Int) ([]*big.Int, error) {
+	ms := make([]*big.Int, len(cs))
+	for i, c := range cs {
+		if c == nil {
+			return nil, fmt.Errorf("paillier: element %d: %w", i, ErrCiphertextInvalid)
+		}
+		m, err := priv.Decrypt(c)
+		if err != nil {
+			return nil, fmt.Errorf("paillier: element %d: %w", i, err)
+		}
+		ms[i] = m
+	}
+	return ms, nil
+}
+
+// AddVectors 对两个等长密文向量逐元素做同态加法：返回 Enc(a_i + b_i)
+func (pub *PublicKey) AddVectors(a, b []*big.Int) ([]*big.Int, error) {
+	if len(a) != len(b) {
+		return nil, fmt.Errorf("paillier: vector length mismatch: %d != %d", len(a), len(b))
+	}
+	sums := make([]*big.Int, len(a))
+	for i := range a {
+		if a[i] == nil || b[i] == nil {
+			return nil, fmt.Errorf("paillier: element %d: %w", i, ErrCiphertextInvalid)
+		}
+		sum, err := pub.Add(a[i], b[i])
+		if err != nil {
+			return nil, fmt.Errorf("paillier: element %d: %w", i, err)
+		}
+		sums[i] = sum
+	}
+	return sums, nil
+}
@@ -0,0 +1,80 @@
+package paillier
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestVectorOperations(t *testing.T) {
+	priv := testKey(t)
+	pub := priv.Public()
+
+	a := []*big.Int{big.NewInt(1), big.NewInt(20), big.NewInt(300), big.NewInt(0)}
+	b := []*big.Int{big.NewInt(4), big.NewInt(50), big.NewInt(600), new(big.Int).Sub(priv.N, bigOne)}
+
+	t.Run("逐元素加解密", func(t *testing.T) {
+		cs, err := pub.EncryptVector(rand.Reader, a)
+		if err != nil {
+			t.Fatalf("EncryptVector 失败: %v", err)
+		}
+		ms, err := priv.DecryptVector(cs)
+		if err != nil {
+			t.Fatalf("DecryptVector 失败: %v", err)
+		}
+		for i := range a {
+			if ms[i].Cmp(a[i]) != 0 {
+				t.Errorf("元素 %d: 期望 %v, 得到 %v", i, a[i], ms[i])
+			}
+		}
+	})
+
+	t.Run("逐元素同态加法", func(t *testing.T) {
+		ca, _ := pub.EncryptVector(rand.Reader, a)
+		cb, _ := pub.EncryptVector(rand.Reader, b)
+
+		cs, err := pub.AddVectors(ca, cb)
+		if err != nil {
+			t.Fatalf("AddVectors 失败: %v", err)
+		}
+		sums, err := priv.DecryptVector(cs)
+		if err != nil {
+			t.Fatalf("DecryptVector 失败: %v", err)
+		}
+		for i := range a {
+			expected := new(big.Int).Add(a[i], b[i])
+			expected.Mod(expected, priv.N)
+			if sums[i].Cmp(expected) != 0 {
+				t.Errorf("元素 %d: 期望 %v, 得到 %v", i, expected, sums[i])
+			}
+		}
+	})
+
+	t.Run("长度不一致", func(t *testing.T) {
+		ca, _ := pub.EncryptVector(rand.Reader, a)
+		cb, _ := pub.EncryptVector(rand.Reader, b[:2])
+		if _, err := pub.AddVectors(ca, cb); err == nil {
+			t.Error("应该返回错误当向量长度不一致")
+		}
+	})
+
+	t.Run("非法元素报告下标", func(t *testing.T) {
+		bad := []*big.Int{big.NewInt(1), big.NewInt(-1)}
+		_, err := pub.EncryptVector(rand.Reader, bad)
+		if err == nil {
+			t.Fatal("应该返回错误当元素为负数")
+		}
+		if !strings.Contains(err.Error(), "element 1") || !errors.Is(err, ErrMessageTooLarge) {
+			t.Errorf("错误应该指出下标 1 并包装原始错误, 得到 %v", err)
+		}
+
+		cs, _ := pub.EncryptVector(rand.Reader, a)
+		cs[2] = big.NewInt(0)
+		_, err = priv.DecryptVector(cs)
+		if err == nil || !strings.Contains(err.Error(), "element 2") {
+			t.Errorf("错误应该指出下标 2, 得到 %v", err)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package prime
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+)
+
+// ================= 安全素数对 =================
+
+// pairDistanceSlack 是距离下界 2^(bits/2 - pairDistanceSlack) 中的余量。
+// |p - q| < 2^(bits/2) 量级时 Fermat 分解法可以很快分解 N = p·q，
+// 留出 100 位余量后，随机生成的两个素数被拒绝的概率约 2^-99
+const pairDistanceSlack = 100
+
+// pairMaxAttempts 是重新生成 q 的次数上限，正常随机源下几乎不会用到第二次
+const pairMaxAttempts = 16
+
+// ErrPairTooClose 表示多次重试后仍得不到距离足够远的安全素数对（通常说明随机源有问题）
+var ErrPairTooClose = errors.New("safe primes too close: |p - q| below the minimum distance")
+
+// GenerateSafePrimePair 生成两个 bits 位的安全素数 p、q，并保证
+// |p.P - q.P| > 2^(bits/2 - 100)（bits/2 <= 100 时只要求 p != q），
+// 避免把它们作为 RSA/Paillier 模数的因子时被 Fermat 分解。距离不够时保留 p、重新生成 q
+func GenerateSafePrimePair(bits int, cfg *Config, r io.Reader) (p, q *SafePrime, err error) {
+	if r == nil {
+		r = rand.Reader
+	}
+	p, err = GenerateSafePrime(bits, cfg, r)
+	if err != nil {
+		return nil, nil, err
+	}
+	for attempt := 0; attempt < pairMaxAttempts; attempt++ {
+		q, err = GenerateSafePrime(bits, cfg, r)
+		if err != nil {
+			return nil, nil, err
+		}
+		if farEnough(p.P, q.P, bits) {
+			return p, q, nil
+		}
+	}
+	return nil, nil, ErrPairTooClose
+}
+
+// minPairDistance 返回 bits 位安全素数对要求的距离下界 2^max(bits/2 - 100, 0)
+func minPairDistance(bits int) *big.Int {
+	e := bits/2 - pairDistanceSlack
+	if e < 0 {
+		e = 0
+	}
+	return new(big.Int).Lsh(bigOne, uint(e))
+}
+
+// farEnough 判断 |a - b| > minPairDistance(bits)
+func farEnough(a, b *big.Int, bits int) bool {
+	d := new(big.Int).Sub(a, b)
+	return d.Abs(d).Cmp(minPairDistance(bits)) > 0
+}
@@ -0,0 +1,84 @@
+package prime
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestGenerateSafePrimePair(t *testing.T) {
+	t.Run("多次生成的距离都超过下界", func(t *testing.T) {
+		const bits = 512
+		bound := minPairDistance(bits)
+		if bound.BitLen() != bits/2-pairDistanceSlack+1 {
+			t.Fatalf("下界应该是 2^%d, 得到 %d 位", bits/2-pairDistanceSlack, bound.BitLen())
+		}
+		for i := 0; i < 4; i++ {
+			p, q, err := GenerateSafePrimePair(bits, nil, nil)
+			if err != nil {
+				t.Fatalf("生成安全素数对失败: %v", err)
+			}
+			verifySafePrime(t, p, bits)
+			verifySafePrime(t, q, bits)
+			d := new(big.Int).Sub(p.P, q.P)
+			if d.Abs(d).Cmp(bound) <= 0 {
+				t.Errorf("第 %d 次: |p - q| = %v 不应该小于等于下界", i, d)
+			}
+		}
+	})
+
+	t.Run("小位数只要求 p != q", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.SmallBitsMode = true
+		for i := 0; i < 8; i++ {
+			p, q, err := GenerateSafePrimePair(16, cfg, nil)
+			if err != nil {
+				t.Fatalf("生成安全素数对失败: %v", err)
+			}
+			if p.P.Cmp(q.P) == 0 {
+				t.Fatal("p 和 q 不应该相等")
+			}
+		}
+	})
+
+	t.Run("参数错误透传", func(t *testing.T) {
+		if _, _, err := GenerateSafePrimePair(2, nil, nil); err == nil {
+			t.Error("应该返回错误当 bits 过小")
+		}
+	})
+}
+
+func TestFarEnough(t *testing.T) {
+	const bits = 2048
+	bound := minPairDistance(bits) // 2^924
+	base := new(big.Int).Lsh(bigOne, bits-1)
+
+	cases := []struct {
+		name string
+		d    *big.Int
+		want bool
+	}{
+		{"相等", big.NewInt(0), false},
+		{"恰好等于下界", bound, false},
+		{"下界加一", new(big.Int).Add(bound, bigOne), true},
+		{"远大于下界", new(big.Int).Lsh(bound, 50), true},
+		{"很近", big.NewInt(1 << 20), false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			other := new(big.Int).Add(base, tc.d)
+			if got := farEnough(base, other, bits); got != tc.want {
+				t.Errorf("farEnough(a, a+d) = %v, 期望 %v", got, tc.want)
+			}
+			// 顺序无关
+			if got := farEnough(other, base, bits); got != tc.want {
+				t.Errorf("farEnough(a+d, a) = %v, 期望 %v", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("小位数下界为 1", func(t *testing.T) {
+		if minPairDistance(64).Cmp(bigOne) != 0 {
+			t.Error("bits/2 <= 100 时下界应该为 1")
+		}
+	})
+}
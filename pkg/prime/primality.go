@@ -0,0 +1,232 @@
+package prime
+
+import (
+	"math/big"
+)
+
+// ================= 小素数试除 =================
+
+// SmallFactor 用 2、3 以及 Wiener 筛的小素数表（1000 以内的全部素数）对 n 试除，
+// 返回找到的最小素因子。n 本身就是小素数时不算有小因子。
+// 每组素数先对其乘积取一次模，再在 uint64 上逐个检查，避免对大数反复取模。
+func SmallFactor(n *big.Int) (uint64, bool) {
+	if n.Sign() <= 0 {
+		return 0, false
+	}
+	// n 等于 p 时 p 不算 n 的"小因子"
+	isSelf := func(p uint64) bool {
+		return n.IsUint64() && n.Uint64() == p
+	}
+
+	if n.Bit(0) == 0 && !isSelf(2) {
+		return 2, true
+	}
+	rem := new(big.Int)
+	if rem.Mod(n, bigThree).Sign() == 0 && !isSelf(3) {
+		return 3, true
+	}
+	for i, group := range primesGroups {
+		r := rem.Mod(n, primeProductsBig[i]).Uint64()
+		for _, p := range group {
+			if r%p == 0 && !isSelf(p) {
+				return p, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// ================= Baillie-PSW =================
+
+// IsProbablePrime 是推荐的默认素性测试（Baillie-PSW）：
+// 小素数试除 → 底数 2 的强伪素数测试 → 强 Lucas 测试。
+// 调用方无需再为 ProbablyPrime 选择轮数。
+//
+// 对 n < 2^64 已验证不存在 BPSW 伪素数，结果是确定的；对更大的 n 至今没有已知反例，
+// 误判概率可以忽略（远低于随机底数 Miller-Rabin 数十轮的 4^-k 上界）。
+func IsProbablePrime(n *big.Int) bool {
+	if n.Cmp(bigTwo) < 0 {
+		return false
+	}
+	if _, ok := SmallFactor(n); ok {
+		return false
+	}
+	return MillerRabinBases(n, []uint64{2}) && StrongLucas(n)
+}
+
+// ================= Miller-Rabin（指定底数） =================
+
+// MillerRabinBases 对 n 用调用方给定的底数逐一做强伪素数测试。
+// 任一底数成为合数见证即返回 false；全部通过返回 true。
+// 配合已知的确定性底数集合（例如 n < 3,215,031,751 时取 {2,3,5,7}），
+// 可以在有界范围内给出确定的素性判定。
+// 与 n 同余于 0 的底数不提供信息，直接跳过。
+func MillerRabinBases(n *big.Int, bases []uint64) bool {
+	if n.Cmp(bigTwo) < 0 {
+		return false
+	}
+	if n.Cmp(bigTwo) == 0 || n.Cmp(bigThree) == 0 {
+		return true
+	}
+	if n.Bit(0) == 0 {
+		return false
+	}
+
+	// n-1 = d * 2^s，d 为奇数
+	nMinusOne := new(big.Int).Sub(n, bigOne)
+	s := nMinusOne.TrailingZeroBits()
+	d := new(big.Int).Rsh(nMinusOne, s)
+
+	a := new(big.Int)
+	for _, base := range bases {
+		a.SetUint64(base)
+		a.Mod(a, n)
+		if a.Sign() == 0 {
+			continue
+		}
+		if !strongProbablePrime(n, nMinusOne, d, s, a) {
+			return false
+		}
+	}
+	return true
+}
+
+// strongProbablePrime：检查 n 对底数 a 是否为强伪素数。
+// 调用侧保证 n 为奇数且 n-1 = d * 2^s。
+func strongProbablePrime(n, nMinusOne, d *big.Int, s uint, a *big.Int) bool {
+	x := new(big.Int).Exp(a, d, n)
+	if x.Cmp(bigOne) == 0 || x.Cmp(nMinusOne) == 0 {
+		return true
+	}
+	for i := uint(1); i < s; i++ {
+		x.Mul(x, x)
+		x.Mod(x, n)
+		if x.Cmp(nMinusOne) == 0 {
+			return true
+		}
+		if x.Cmp(bigOne) == 0 {
+			return false
+		}
+	}
+	return false
+}
+
+// ================= 强 Lucas 测试 =================
+
+// StrongLucas 对 n 做强 Lucas 概率素数测试（Selfridge 方法 A 选参）。
+// 参数选取：在 D = 5, -7, 9, -11, ... 中找第一个 Jacobi(D/n) = -1 的 D，
+// 取 P = 1, Q = (1-D)/4。完全平方数会让该搜索不终止，因此预先排除。
+// 返回 false ⇒ n 一定是合数；返回 true ⇒ n 是素数或强 Lucas 伪素数。
+func StrongLucas(n *big.Int) bool {
+	if n.Cmp(bigTwo) < 0 {
+		return false
+	}
+	if n.Cmp(bigTwo) == 0 {
+		return true
+	}
+	if n.Bit(0) == 0 {
+		return false
+	}
+	if isPerfectSquare(n) {
+		return false
+	}
+
+	// 1. Selfridge 方法 A：寻找 Jacobi(D/n) = -1 的 D。
+	D, ok := selfridgeD(n)
+	if !ok {
+		return false
+	}
+
+	// Q = (1-D)/4 (mod n)
+	Q := new(big.Int).Sub(bigOne, D)
+	Q.Rsh(Q, 2) // 1-D ≡ 0 (mod 4)，Rsh 对负数向下取整，此处恰好整除
+	Q.Mod(Q, n)
+	Dmod := new(big.Int).Mod(D, n)
+
+	// 2. n+1 = d * 2^s，d 为奇数
+	nPlusOne := new(big.Int).Add(n, bigOne)
+	s := nPlusOne.TrailingZeroBits()
+	d := new(big.Int).Rsh(nPlusOne, s)
+
+	// 3. 从 d 的最高位开始二进制展开，计算 U_d, V_d, Q^d（P = 1）。
+	U := big.NewInt(1)
+	V := big.NewInt(1)
+	Qk := new(big.Int).Set(Q)
+	tmp := new(big.Int)
+	for i := d.BitLen() - 2; i >= 0; i-- {
+		// 倍增：U_2k = U_k V_k, V_2k = V_k^2 - 2Q^k, Q^2k = (Q^k)^2
+		U.Mul(U, V)
+		U.Mod(U, n)
+		V.Mul(V, V)
+		V.Sub(V, tmp.Lsh(Qk, 1))
+		V.Mod(V, n)
+		Qk.Mul(Qk, Qk)
+		Qk.Mod(Qk, n)
+
+		if d.Bit(i) == 1 {
+			// 加一：U_{k+1} = (U_k + V_k)/2, V_{k+1} = (D U_k + V_k)/2
+			newU := new(big.Int).Add(U, V)
+			halveMod(newU, n)
+			V.Add(tmp.Mul(Dmod, U), V)
+			halveMod(V, n)
+			U = newU
+			Qk.Mul(Qk, Q)
+			Qk.Mod(Qk, n)
+		}
+	}
+
+	// 4. 强 Lucas 条件：U_d ≡ 0，或某个 0 <= r < s 使 V_{d·2^r} ≡ 0。
+	if U.Sign() == 0 || V.Sign() == 0 {
+		return true
+	}
+	for r := uint(1); r < s; r++ {
+		V.Mul(V, V)
+		V.Sub(V, tmp.Lsh(Qk, 1))
+		V.Mod(V, n)
+		if V.Sign() == 0 {
+			return true
+		}
+		Qk.Mul(Qk, Qk)
+		Qk.Mod(Qk, n)
+	}
+	return false
+}
+
+// selfridgeD 按 5, -7, 9, -11, ... 搜索 Jacobi(D/n) = -1 的 D。
+// 若遇到与 n 有公因子的 D（且 |D| != n），说明 n 为合数，返回 false。
+func selfridgeD(n *big.Int) (*big.Int, bool) {
+	D := big.NewInt(5)
+	absD := new(big.Int)
+	dMod := new(big.Int)
+	for {
+		j := big.Jacobi(dMod.Mod(D, n), n)
+		if j == -1 {
+			return D, true
+		}
+		if j == 0 && absD.Abs(D).Cmp(n) != 0 {
+			return nil, false
+		}
+		// 下一个候选：|D| += 2，符号翻转
+		if D.Sign() > 0 {
+			D.Add(D, bigTwo)
+		} else {
+			D.Sub(D, bigTwo)
+		}
+		D.Neg(D)
+	}
+}
+
+// halveMod 原地计算 x/2 (mod n)，n 为奇数，x 非负。
+func halveMod(x, n *big.Int) {
+	if x.Bit(0) == 1 {
+		x.Add(x, n)
+	}
+	x.Rsh(x, 1)
+	x.Mod(x, n)
+}
+
+// isPerfectSquare：n 是否为完全平方数。
+func isPerfectSquare(n *big.Int) bool {
+	root := new(big.Int).Sqrt(n)
+	return root.Mul(root, root).Cmp(n) == 0
+}
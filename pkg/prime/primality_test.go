@@ -0,0 +1,186 @@
+package prime
+
+import (
+	"math/big"
+	"testing"
+)
+
+// n < 3,215,031,751 时 {2,3,5,7} 是确定性底数集合
+var deterministicBases = []uint64{2, 3, 5, 7}
+
+// ================= Miller-Rabin（指定底数）测试 =================
+
+func TestMillerRabinBases(t *testing.T) {
+	t.Run("小整数与 ProbablyPrime 一致", func(t *testing.T) {
+		for i := int64(0); i < 5000; i++ {
+			n := big.NewInt(i)
+			got := MillerRabinBases(n, deterministicBases)
+			expected := n.ProbablyPrime(20)
+			if got != expected {
+				t.Errorf("n = %d: 期望 %v, 得到 %v", i, expected, got)
+			}
+		}
+	})
+
+	t.Run("界内的强伪素数被识别为合数", func(t *testing.T) {
+		// 底数 2 的强伪素数
+		for _, v := range []int64{2047, 3277, 4033, 4681, 8321} {
+			if MillerRabinBases(big.NewInt(v), deterministicBases) {
+				t.Errorf("%d 应该被判定为合数", v)
+			}
+		}
+	})
+
+	t.Run("界内的大素数", func(t *testing.T) {
+		for _, v := range []int64{2147483647, 3215031749, 999999937} {
+			if !MillerRabinBases(big.NewInt(v), deterministicBases) {
+				t.Errorf("%d 应该被判定为素数", v)
+			}
+		}
+	})
+
+	t.Run("界上的伪素数需要更多底数", func(t *testing.T) {
+		// 3215031751 = 151 * 751 * 28351 是 {2,3,5,7} 的强伪素数
+		n := big.NewInt(3215031751)
+		if !MillerRabinBases(n, deterministicBases) {
+			t.Error("3215031751 应该通过 {2,3,5,7}")
+		}
+		if MillerRabinBases(n, []uint64{2, 3, 5, 7, 11}) {
+			t.Error("3215031751 应该被底数 11 识破")
+		}
+	})
+}
+
+// ================= 强 Lucas 测试 =================
+
+// 100000 以内的强 Lucas 伪素数（Selfridge 方法 A，OEIS A217255）
+var strongLucasPseudoprimes = []int64{
+	5459, 5777, 10877, 16109, 18971, 22499, 24569, 25199, 40309, 58519, 75077, 97439,
+}
+
+func TestStrongLucas(t *testing.T) {
+	t.Run("100000 以内分类正确", func(t *testing.T) {
+		pseudo := make(map[int64]bool)
+		for _, v := range strongLucasPseudoprimes {
+			pseudo[v] = true
+		}
+		for i := int64(0); i < 100000; i++ {
+			n := big.NewInt(i)
+			expected := n.ProbablyPrime(20) || pseudo[i]
+			if got := StrongLucas(n); got != expected {
+				t.Errorf("n = %d: 期望 %v, 得到 %v", i, expected, got)
+			}
+		}
+	})
+
+	t.Run("伪素数通过 Lucas 但被 Miller-Rabin 识破", func(t *testing.T) {
+		for _, v := range strongLucasPseudoprimes {
+			n := big.NewInt(v)
+			if !StrongLucas(n) {
+				t.Errorf("%d 应该通过强 Lucas 测试", v)
+			}
+			if MillerRabinBases(n, []uint64{2}) {
+				t.Errorf("%d 应该被底数 2 的 Miller-Rabin 识破", v)
+			}
+		}
+	})
+
+	t.Run("完全平方数", func(t *testing.T) {
+		for _, v := range []int64{1, 4, 9, 25, 49, 121, 10201} {
+			if StrongLucas(big.NewInt(v)) {
+				t.Errorf("完全平方数 %d 不应该通过", v)
+			}
+		}
+	})
+
+	t.Run("大素数", func(t *testing.T) {
+		// 2^127 - 1 是梅森素数
+		n := new(big.Int).Lsh(bigOne, 127)
+		n.Sub(n, bigOne)
+		if !StrongLucas(n) {
+			t.Error("2^127 - 1 应该通过强 Lucas 测试")
+		}
+		// 2^128 + 1 = 59649589127497217 * 5704689200685129054721
+		c := new(big.Int).Lsh(bigOne, 128)
+		c.Add(c, bigOne)
+		if StrongLucas(c) {
+			t.Error("2^128 + 1 不应该通过强 Lucas 测试")
+		}
+	})
+}
+
+func TestSmallFactor(t *testing.T) {
+	bigPrime, _ := new(big.Int).SetString("170141183460469231731687303715884105727", 10) // 2^127 - 1
+
+	cases := []struct {
+		name   string
+		n      *big.Int
+		factor uint64
+		found  bool
+	}{
+		{"偶数", big.NewInt(1 << 20), 2, true},
+		{"3 * 大素数", new(big.Int).Mul(big.NewInt(3), bigPrime), 3, true},
+		{"991 * 大素数", new(big.Int).Mul(big.NewInt(991), bigPrime), 991, true},
+		{"最小因子优先", big.NewInt(7 * 5 * 997), 5, true},
+		{"大素数", bigPrime, 0, false},
+		{"1009 * 大素数（超出表范围）", new(big.Int).Mul(big.NewInt(1009), bigPrime), 0, false},
+		{"小素数本身", big.NewInt(983), 0, false},
+		{"2 本身", big.NewInt(2), 0, false},
+		{"1", big.NewInt(1), 0, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			factor, found := SmallFactor(tc.n)
+			if found != tc.found || factor != tc.factor {
+				t.Errorf("期望 (%d, %v), 得到 (%d, %v)", tc.factor, tc.found, factor, found)
+			}
+		})
+	}
+}
+
+// ================= Baillie-PSW 测试 =================
+
+func TestIsProbablePrime(t *testing.T) {
+	t.Run("与 ProbablyPrime 在小范围内一致", func(t *testing.T) {
+		for i := int64(-5); i < 20000; i++ {
+			n := big.NewInt(i)
+			want := i > 1 && n.ProbablyPrime(20)
+			if got := IsProbablePrime(n); got != want {
+				t.Fatalf("IsProbablePrime(%d) = %v, 期望 %v", i, got, want)
+			}
+		}
+	})
+
+	t.Run("Carmichael 数", func(t *testing.T) {
+		for _, n := range []int64{561, 1105, 1729, 2465, 2821, 6601, 8911, 41041, 825265, 321197185} {
+			if IsProbablePrime(big.NewInt(n)) {
+				t.Errorf("Carmichael 数 %d 应该被判为合数", n)
+			}
+		}
+	})
+
+	t.Run("强伪素数与 Lucas 伪素数", func(t *testing.T) {
+		// 底数 2 的强伪素数，以及强 Lucas 伪素数
+		for _, n := range []int64{2047, 3277, 4033, 4681, 8321, 3215031751, 5459, 5777, 10877, 16109, 18971} {
+			if IsProbablePrime(big.NewInt(n)) {
+				t.Errorf("伪素数 %d 应该被判为合数", n)
+			}
+		}
+	})
+
+	t.Run("大数", func(t *testing.T) {
+		m127 := new(big.Int).Sub(new(big.Int).Lsh(bigOne, 127), bigOne)
+		m521 := new(big.Int).Sub(new(big.Int).Lsh(bigOne, 521), bigOne)
+		for _, p := range []*big.Int{m127, m521} {
+			if !IsProbablePrime(p) {
+				t.Errorf("梅森素数 2^%d - 1 应该被判为素数", p.BitLen())
+			}
+		}
+		if IsProbablePrime(new(big.Int).Mul(m127, m127)) {
+			t.Error("完全平方数应该被判为合数")
+		}
+		if IsProbablePrime(new(big.Int).Mul(m127, m521)) {
+			t.Error("两个大素数的乘积应该被判为合数")
+		}
+	})
+}
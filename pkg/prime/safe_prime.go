@@ -16,19 +16,190 @@ type SafePrime struct {
 	Q *big.Int // (P-1)/2
 }
 
+// SophieGermain 返回 Sophie Germain 素数 q = (P-1)/2。
+func (sp *SafePrime) SophieGermain() *big.Int {
+	return sp.Q
+}
+
+// IsValid 快速自检：P == 2Q+1 且 P、Q 都是奇数。
+// 不做素性测试，仅用于断言等廉价检查。
+func (sp *SafePrime) IsValid() bool {
+	if sp == nil || sp.P == nil || sp.Q == nil {
+		return false
+	}
+	if sp.P.Bit(0) != 1 || sp.Q.Bit(0) != 1 {
+		return false
+	}
+	twoQPlusOne := new(big.Int).Lsh(sp.Q, 1)
+	twoQPlusOne.Add(twoQPlusOne, bigOne)
+	return sp.P.Cmp(twoQPlusOne) == 0
+}
+
+// ================= 校验 =================
+
+// VerifySophieGermain 可区分的错误
+var (
+	ErrQComposite = errors.New("q is composite")
+	ErrPComposite = errors.New("2q+1 is composite")
+)
+
+// VerifySophieGermain 以 rounds 轮 Miller-Rabin 检查 q 与 2q+1 均为素数。
+// q 为合数返回 ErrQComposite，2q+1 为合数返回 ErrPComposite。
+// 供导入外部安全素数的调用方按所需安全级别校验。
+func VerifySophieGermain(q *big.Int, rounds int) error {
+	if q == nil {
+		return errors.New("q is nil")
+	}
+	if rounds < 1 {
+		return errors.New("rounds must be at least 1")
+	}
+	if !q.ProbablyPrime(rounds) {
+		return ErrQComposite
+	}
+	p := new(big.Int).Lsh(q, 1)
+	p.Add(p, bigOne)
+	if !p.ProbablyPrime(rounds) {
+		return ErrPComposite
+	}
+	return nil
+}
+
+// SafePrimeReason 标识 VerifySafePrime 失败的原因
+type SafePrimeReason int
+
+const (
+	// ReasonRelation 表示 P != 2Q+1（或字段缺失）
+	ReasonRelation SafePrimeReason = iota + 1
+	// ReasonQComposite 表示 Q 是合数
+	ReasonQComposite
+	// ReasonPComposite 表示 P 是合数
+	ReasonPComposite
+)
+
+func (r SafePrimeReason) String() string {
+	switch r {
+	case ReasonRelation:
+		return "p != 2q+1"
+	case ReasonQComposite:
+		return "q is composite"
+	case ReasonPComposite:
+		return "p is composite"
+	}
+	return "unknown"
+}
+
+// SafePrimeError 是 VerifySafePrime 返回的错误，调用方可以按 Reason 区分失败原因。
+// 两种合数情形分别可用 errors.Is 匹配 ErrQComposite、ErrPComposite
+type SafePrimeError struct {
+	Reason SafePrimeReason
+}
+
+func (e *SafePrimeError) Error() string {
+	return "invalid safe prime: " + e.Reason.String()
+}
+
+// Unwrap 把合数情形映射到对应的哨兵错误
+func (e *SafePrimeError) Unwrap() error {
+	switch e.Reason {
+	case ReasonQComposite:
+		return ErrQComposite
+	case ReasonPComposite:
+		return ErrPComposite
+	}
+	return nil
+}
+
+// VerifySafePrime 先检查 P = 2Q+1，再以 rounds 轮 Miller-Rabin 检查 Q、P 均为素数。
+// 失败时返回 *SafePrimeError，Reason 指明是关系不成立、Q 为合数还是 P 为合数
+func VerifySafePrime(sp *SafePrime, rounds int) error {
+	if rounds < 1 {
+		return errors.New("rounds must be at least 1")
+	}
+	if !sp.IsValid() {
+		return &SafePrimeError{Reason: ReasonRelation}
+	}
+	if !sp.Q.ProbablyPrime(rounds) {
+		return &SafePrimeError{Reason: ReasonQComposite}
+	}
+	if !sp.P.ProbablyPrime(rounds) {
+		return &SafePrimeError{Reason: ReasonPComposite}
+	}
+	return nil
+}
+
 type Config struct {
-	// 每个随机起点 q0，局部窗口最大偏移量（按 delta 计），实际候选数约 WindowDeltaMax/6
+	// 每个随机起点 q0，局部窗口最大偏移量（按 delta 计），实际候选数约 WindowDeltaMax/6。
+	// 为 0 时按位数自动取值，见 autoWindowDelta
 	WindowDeltaMax uint64
 
 	// Miller-Rabin 轮数（对 q 和 p 都使用）
 	MillerRabinRounds int
 
+	// 分别为 q、p 指定 Miller-Rabin 轮数，为 0 时使用 MillerRabinRounds
+	MillerRabinRoundsQ int
+	MillerRabinRoundsP int
+
 	// 是否对 q/p 做 Fermat(base=2) 预筛
 	UseFermatQ bool
 	UseFermatP bool
 
 	// 组合筛时是否额外剔除 q ≡ 1 (mod r)，提高 (q-1)/2 为素数的概率
 	FilterForSophie bool
+
+	// 为 true 时强制 q（从而 p）的最高两位为 1，使两个这样的素数之积恰好是 2·bits 位；
+	// 为 false 时只强制最高位，输出分布更宽。
+	// 默认为 true：DefaultConfig、FastConfig、ParanoidConfig 都会设置它，
+	// 手工构造 Config 时需显式设置，否则零值只强制最高位
+	SetTopTwoBits bool
+
+	// 要求 p mod 4 的余数：0 = 不限制，1 或 3 = 必须落在该剩余类。
+	// p = 2q+1 且 q 为奇素数时 2q ≡ 2 (mod 4)，于是 p ≡ 3 (mod 4) 恒成立；
+	// p ≡ 1 (mod 4) 要求 q 为偶数，对 q > 2 不可满足，生成时直接报错。
+	PMod4 int
+
+	// 小位数模式：bits < 64 时不强制 q 的次高位等结构，也跳过组合筛，
+	// 只对候选做 MR 并拒绝位长不对的 p。小位数下组合筛会误杀本身就是筛表素数的 q，
+	// 强制高位又使窗口很快越过 2^bits，导致生成缓慢甚至无法结束
+	SmallBitsMode bool
+}
+
+// smallBitsThreshold 是 SmallBitsMode 生效的位数上界（不含）
+const smallBitsThreshold = 64
+
+// 窗口自动取值的下界与上界
+const (
+	minAutoWindowDelta = 1024
+	maxAutoWindowDelta = 16384
+)
+
+// autoWindowDelta 按位数缩放窗口：安全素数的平均间距约与 bits^2 成正比，
+// 取 1024·(bits/1024)^2，使每个 q0 覆盖的间距比例大致不变，并限制在 [1024, 16384]。
+// 2048 位为 4096，3072 位为 9216，4096 位为 16384。
+//
+// 关于 3072/4096 位的建议：窗口大小对耗时影响很小。实测（2048–4096 位）
+// 每次重新随机 q0 的开销约 10–20µs，而 1024 的窗口平均约有 1.6 个候选通过组合筛
+// （通过率约 0.9%），每个候选至少要做一次 p 的 Fermat 测试，2048/3072/4096 位
+// 分别约 3/11/25ms，因此重启开销不到 1%，不会出现“窗口太小导致频繁重启”的瓶颈。
+// 大位数的耗时主要来自需要测试的候选数（约与 bits^2 成正比）乘以单次模幂的代价，
+// 3072 位单个安全素数通常需要数分钟；需要多个时应并行生成，或离线预生成后导入
+// 并用 VerifySafePrime 校验
+func autoWindowDelta(bits int) uint64 {
+	w := uint64(bits) * uint64(bits) / 1024
+	if w < minAutoWindowDelta {
+		w = minAutoWindowDelta
+	}
+	if w > maxAutoWindowDelta {
+		w = maxAutoWindowDelta
+	}
+	return w
+}
+
+// windowDelta 返回生成 bits 位安全素数时实际使用的窗口
+func (c *Config) windowDelta(bits int) uint64 {
+	if c.WindowDeltaMax > 0 {
+		return c.WindowDeltaMax
+	}
+	return autoWindowDelta(bits)
 }
 
 func DefaultConfig() *Config {
@@ -38,6 +209,55 @@ func DefaultConfig() *Config {
 		UseFermatQ:        false,
 		UseFermatP:        true,
 		FilterForSophie:   true,
+		SetTopTwoBits:     true,
+	}
+}
+
+// roundsQ 返回对 q 使用的 Miller-Rabin 轮数
+func (c *Config) roundsQ() int {
+	if c.MillerRabinRoundsQ > 0 {
+		return c.MillerRabinRoundsQ
+	}
+	return c.MillerRabinRounds
+}
+
+// roundsP 返回对 p 使用的 Miller-Rabin 轮数
+func (c *Config) roundsP() int {
+	if c.MillerRabinRoundsP > 0 {
+		return c.MillerRabinRoundsP
+	}
+	return c.MillerRabinRounds
+}
+
+// ================= 预设配置 =================
+
+// FastConfig 适合测试或低风险场景：较小的窗口、8 轮 Miller-Rabin。
+// 8 轮随机底数对随机候选的误判概率已远低于 2^-80，但不适合对抗性输入
+func FastConfig() *Config {
+	return &Config{
+		WindowDeltaMax:    256,
+		MillerRabinRounds: 8,
+		UseFermatQ:        false,
+		UseFermatP:        true,
+		FilterForSophie:   true,
+		SetTopTwoBits:     true,
+	}
+}
+
+// BalancedConfig 即 DefaultConfig：1024 的窗口、32 轮 Miller-Rabin
+func BalancedConfig() *Config {
+	return DefaultConfig()
+}
+
+// ParanoidConfig 适合长期密钥：4096 的窗口、64 轮 Miller-Rabin，并对 q、p 都做 Fermat 预筛
+func ParanoidConfig() *Config {
+	return &Config{
+		WindowDeltaMax:    4096,
+		MillerRabinRounds: 64,
+		UseFermatQ:        true,
+		UseFermatP:        true,
+		FilterForSophie:   true,
+		SetTopTwoBits:     true,
 	}
 }
 
@@ -137,6 +357,13 @@ func GenerateSafePrime(bits int, cfg *Config, r io.Reader) (*SafePrime, error) {
 	if r == nil {
 		r = rand.Reader
 	}
+	switch cfg.PMod4 {
+	case 0, 3:
+	case 1:
+		return nil, errors.New("p ≡ 1 (mod 4) is unreachable: q is odd so p = 2q+1 ≡ 3 (mod 4)")
+	default:
+		return nil, errors.New("PMod4 must be 0, 1 or 3")
+	}
 
 	gen := &generator{cfg: cfg, rand: r}
 	return gen.generate(bits)
@@ -164,10 +391,12 @@ func (g *generator) generate(bits int) (*SafePrime, error) {
 	}
 
 	buf := make([]byte, byteLen)
+	small := g.cfg.SmallBitsMode && bits < smallBitsThreshold
+	window := g.cfg.windowDelta(bits)
 
 	for {
-		// 1. 生成 q0（bit 长度约 qBits，最高两位为 1，奇数）。
-		q0, err := g.randomQ0(buf, qBits, highBits)
+		// 1. 生成 q0（bit 长度约 qBits，最高位为 1（可选最高两位），奇数）。
+		q0, err := g.randomQ0(buf, qBits, highBits, small)
 		if err != nil {
 			return nil, err
 		}
@@ -181,12 +410,17 @@ func (g *generator) generate(bits int) (*SafePrime, error) {
 		// 4. 构造 filter pipeline（对每个候选 (q,p) 调用）。
 		filters := g.buildFilters(bits)
 
+		// 4.1 q0 靠近区间顶端时，窗口后段的 q 会超过 qBits 位、p 多出一位。
+		//     delta 单调递增，提前算出窗口上界，位长不对的候选既不过筛也不构造。
+		limit := windowLimit(q0, qBits, window)
+
 		// 5. 在局部窗口里按 delta += 6 扫描候选。
 		//    - q0 已经是奇数且 ≡ 2 (mod 3)
 		//    - delta 按 6 递增 ⇒ q 始终是奇数且 ≡ 2 (mod 3)
-		for delta := uint64(0); delta < g.cfg.WindowDeltaMax; delta += 6 {
+		for delta := uint64(0); delta < limit; delta += 6 {
 			// 5.1 组合筛只依赖 q0/baseRemainders/delta，在构造 candidate 前先过滤掉大部分垃圾。
-			if !passesCombinedSieve(baseRemainders, delta, g.cfg.FilterForSophie) {
+			//     小位数模式下跳过：q 可能就是筛表中的素数，MR 的开销本来也很小。
+			if !small && !passesCombinedSieve(baseRemainders, delta, g.cfg.FilterForSophie) {
 				continue
 			}
 
@@ -208,7 +442,8 @@ func (g *generator) generate(bits int) (*SafePrime, error) {
 
 // ================= step 1：生成初始 q0 =================
 
-func (g *generator) randomQ0(buf []byte, qBits int, highBits uint) (*big.Int, error) {
+// small 为 true 时只强制奇数与最高位，其余位保持随机，位长由 bitLenFilter 兜底。
+func (g *generator) randomQ0(buf []byte, qBits int, highBits uint, small bool) (*big.Int, error) {
 	if _, err := io.ReadFull(g.rand, buf); err != nil {
 		return nil, err
 	}
@@ -223,6 +458,11 @@ func (g *generator) randomQ0(buf []byte, qBits int, highBits uint) (*big.Int, er
 	// 设置最低位为 1，确保 q 是奇数
 	q.SetBit(q, 0, 1)
 
+	if small {
+		q.SetBit(q, qBits-1, 1)
+		return q, nil
+	}
+
 	// 设置第 2 位（索引 1）为 1，鼓励 q 在 ≡ 3 (mod 4) 这一类起步
 	//（后续 normalizeMod3 不依赖这个，只是让初始分布略微“厚一点”）
 	if qBits > 1 {
@@ -232,14 +472,28 @@ func (g *generator) randomQ0(buf []byte, qBits int, highBits uint) (*big.Int, er
 	// 设置最高位为 1，确保位数正确，避免数太小
 	q.SetBit(q, qBits-1, 1)
 
-	// 如果位数 >= 2，也设置次高位为 1，进一步避免数太小
-	if qBits >= 2 {
+	// 如果位数 >= 2 且配置未关闭，也设置次高位为 1，进一步避免数太小
+	if g.cfg.SetTopTwoBits && qBits >= 2 {
 		q.SetBit(q, qBits-2, 1)
 	}
 
 	return q, nil
 }
 
+// windowLimit 返回 min(max, 2^qBits - q0)：delta 小于它时 q = q0 + delta 恰好 qBits 位，
+// p = 2q+1 恰好 qBits+1 位。q0 已超出 qBits 位（normalizeMod3 可能进位）时返回 0。
+func windowLimit(q0 *big.Int, qBits int, max uint64) uint64 {
+	room := new(big.Int).Lsh(bigOne, uint(qBits))
+	room.Sub(room, q0)
+	if room.Sign() <= 0 {
+		return 0
+	}
+	if room.IsUint64() && room.Uint64() < max {
+		return room.Uint64()
+	}
+	return max
+}
+
 // ================= step 2：规范化 mod 3 =================
 
 // 把 q 调整到 q ≡ 2 (mod 3)，这样后面 delta+=6 的候选都不会被 3 整除。
@@ -272,12 +526,18 @@ type filter func(*candidate) bool
 func (g *generator) buildFilters(bits int) []filter {
 	var filters []filter
 
-	// 1) p bit 长度必须正确。
+	// 1) p bit 长度必须正确。windowLimit 已保证窗口内的候选位长正确，这里只作兜底，
+	//    并且放在最前面，确保位长不对的候选不会进入后面更贵的 filter。
 	filters = append(filters, bitLenFilter(bits))
 
 	// 2) p 不能被小素数整除（简单筛，过滤明显合数）。
 	filters = append(filters, smallPrimeFilterForP())
 
+	// 可选：p mod 4 剩余类约束。q 恒为奇数时 p ≡ 3 (mod 4) 自动满足，这里只是廉价兜底。
+	if g.cfg.PMod4 != 0 {
+		filters = append(filters, pMod4Filter(g.cfg.PMod4))
+	}
+
 	// 3) 可选：Fermat base=2 预筛 q/p。
 	if g.cfg.UseFermatQ {
 		filters = append(filters, fermatFilterQ())
@@ -287,8 +547,8 @@ func (g *generator) buildFilters(bits int) []filter {
 	}
 
 	// 4) 最终：对 q/p 做 Miller-Rabin。
-	filters = append(filters, mrFilterQ(g.cfg.MillerRabinRounds))
-	filters = append(filters, mrFilterP(g.cfg.MillerRabinRounds))
+	filters = append(filters, mrFilterQ(g.cfg.roundsQ()))
+	filters = append(filters, mrFilterP(g.cfg.roundsP()))
 
 	return filters
 }
@@ -382,17 +642,24 @@ func smallPrimeFilterForP() filter {
 	}
 }
 
+// p mod 4 必须等于 residue。
+func pMod4Filter(residue int) filter {
+	return func(c *candidate) bool {
+		return int(c.p.Bits()[0]&3) == residue
+	}
+}
+
 // 3) Fermat base=2 对 q 的预筛。
 func fermatFilterQ() filter {
 	return func(c *candidate) bool {
-		return fermatBase2(c.q)
+		return FermatBase2(c.q)
 	}
 }
 
 // 4) Fermat base=2 对 p 的预筛。
 func fermatFilterP() filter {
 	return func(c *candidate) bool {
-		return fermatBase2(c.p)
+		return FermatBase2(c.p)
 	}
 }
 
@@ -412,9 +679,9 @@ func mrFilterP(rounds int) filter {
 
 // ================= Fermat base=2 预筛 =================
 
-// fermatBase2：2^(n-1) ≡ 1 (mod n) ?
-// 不通过 => 一定合数；通过 => 可能是素数，仅作预筛。
-func fermatBase2(n *big.Int) bool {
+// FermatBase2 检查 2^(n-1) ≡ 1 (mod n)，可作为 ProbablyPrime 之前的廉价预筛。
+// 返回 false => n 一定是合数；返回 true => n 可能是素数（Fermat 伪素数如 341 也会通过）。
+func FermatBase2(n *big.Int) bool {
 	if n.Cmp(bigTwo) < 0 {
 		return false
 	}
@@ -2,6 +2,7 @@ package prime
 
 import (
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"math/big"
 	"testing"
@@ -203,6 +204,69 @@ func TestGenerateSafePrime_WithConfig(t *testing.T) {
 		}
 		verifySafePrime(t, sp, 256)
 	})
+
+	t.Run("最高两位结构", func(t *testing.T) {
+		const bits = 128
+		for name, cfg := range map[string]*Config{
+			"默认配置":   DefaultConfig(),
+			"快速配置":   FastConfig(),
+			"严格配置":   ParanoidConfig(),
+			"只强制最高位": {MillerRabinRounds: 20, SetTopTwoBits: false},
+		} {
+			secondBitClear := 0
+			for i := 0; i < 32; i++ {
+				sp, err := GenerateSafePrime(bits, cfg, nil)
+				if err != nil {
+					t.Fatalf("生成安全素数失败: %v", err)
+				}
+				if sp.P.BitLen() != bits {
+					t.Fatalf("p 的位数应该是 %d, 得到 %d", bits, sp.P.BitLen())
+				}
+				if sp.P.Bit(bits-2) == 0 {
+					secondBitClear++
+				}
+			}
+
+			if cfg.SetTopTwoBits && secondBitClear != 0 {
+				t.Errorf("%s: p 的次高位应该总是 1, 有 %d 个为 0", name, secondBitClear)
+			}
+			// 只强制最高位时，次高位近似均匀分布，32 次全为 1 的概率可忽略
+			if !cfg.SetTopTwoBits && secondBitClear == 0 {
+				t.Errorf("%s: p 的次高位应该出现 0", name)
+			}
+		}
+	})
+
+	t.Run("要求 p ≡ 3 (mod 4)", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.PMod4 = 3
+		for i := 0; i < 10; i++ {
+			sp, err := GenerateSafePrime(256, cfg, nil)
+			if err != nil {
+				t.Fatalf("生成安全素数失败: %v", err)
+			}
+			verifySafePrime(t, sp, 256)
+			if r := new(big.Int).Mod(sp.P, bigFour); r.Int64() != 3 {
+				t.Errorf("p mod 4 应该是 3, 得到 %v", r)
+			}
+		}
+	})
+
+	t.Run("p ≡ 1 (mod 4) 不可满足", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.PMod4 = 1
+		if _, err := GenerateSafePrime(256, cfg, nil); err == nil {
+			t.Error("应该返回错误当 PMod4 = 1")
+		}
+	})
+
+	t.Run("非法 PMod4", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.PMod4 = 2
+		if _, err := GenerateSafePrime(256, cfg, nil); err == nil {
+			t.Error("应该返回错误当 PMod4 = 2")
+		}
+	})
 }
 
 // ================= 随机数生成器测试 =================
@@ -246,6 +310,18 @@ func BenchmarkGenerateSafePrime_1024(b *testing.B) {
 	}
 }
 
+// BenchmarkGenerateSafePrime_3072 单次通常需要数分钟，建议配合 -benchtime=1x 运行
+func BenchmarkGenerateSafePrime_3072(b *testing.B) {
+	cfg := DefaultConfig()
+	cfg.WindowDeltaMax = 0
+	for i := 0; i < b.N; i++ {
+		_, err := GenerateSafePrime(3072, cfg, nil)
+		if err != nil {
+			b.Fatalf("生成失败: %v", err)
+		}
+	}
+}
+
 // ================= 配置默认值测试 =================
 
 func TestDefaultConfig(t *testing.T) {
@@ -261,6 +337,226 @@ func TestDefaultConfig(t *testing.T) {
 	}
 }
 
+func TestAutoWindowDelta(t *testing.T) {
+	t.Run("按位数缩放", func(t *testing.T) {
+		cases := map[int]uint64{
+			256:  minAutoWindowDelta,
+			1024: 1024,
+			2048: 4096,
+			3072: 9216,
+			4096: 16384,
+			8192: maxAutoWindowDelta,
+		}
+		for bits, want := range cases {
+			if got := autoWindowDelta(bits); got != want {
+				t.Errorf("autoWindowDelta(%d) = %d, 期望 %d", bits, got, want)
+			}
+		}
+	})
+
+	t.Run("显式配置优先", func(t *testing.T) {
+		cfg := DefaultConfig()
+		if got := cfg.windowDelta(4096); got != cfg.WindowDeltaMax {
+			t.Errorf("应该使用配置的窗口 %d, 得到 %d", cfg.WindowDeltaMax, got)
+		}
+		cfg.WindowDeltaMax = 0
+		if got := cfg.windowDelta(4096); got != autoWindowDelta(4096) {
+			t.Errorf("WindowDeltaMax 为 0 时应该自动取值, 得到 %d", got)
+		}
+	})
+
+	t.Run("自动窗口生成 2048 位安全素数", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip("2048 位安全素数生成较慢")
+		}
+		cfg := DefaultConfig()
+		cfg.WindowDeltaMax = 0
+		sp, err := GenerateSafePrime(2048, cfg, nil)
+		if err != nil {
+			t.Fatalf("生成安全素数失败: %v", err)
+		}
+		verifySafePrime(t, sp, 2048)
+	})
+}
+
+func TestGenerateSafePrime_SmallBitsMode(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.SmallBitsMode = true
+
+	for _, bits := range []int{8, 16, 32} {
+		t.Run(fmt.Sprintf("%d 位", bits), func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				sp, err := GenerateSafePrime(bits, cfg, rand.Reader)
+				if err != nil {
+					t.Fatalf("生成安全素数失败: %v", err)
+				}
+				if sp.P.BitLen() != bits {
+					t.Fatalf("p 应该恰好 %d 位, 得到 %d 位", bits, sp.P.BitLen())
+				}
+				if !sp.IsValid() || !sp.P.ProbablyPrime(20) || !sp.Q.ProbablyPrime(20) {
+					t.Fatalf("%v 不是安全素数", sp.P)
+				}
+			}
+		})
+	}
+
+	t.Run("不影响 64 位及以上", func(t *testing.T) {
+		sp, err := GenerateSafePrime(64, cfg, rand.Reader)
+		if err != nil {
+			t.Fatalf("生成安全素数失败: %v", err)
+		}
+		if sp.P.BitLen() != 64 || sp.P.Bit(62) != 1 {
+			t.Error("64 位时应该仍然强制最高两位")
+		}
+	})
+}
+
+func TestWindowLimit(t *testing.T) {
+	const qBits = 64
+	top := new(big.Int).Lsh(big.NewInt(1), qBits)
+
+	t.Run("远离顶端时取完整窗口", func(t *testing.T) {
+		q0 := new(big.Int).Lsh(big.NewInt(1), qBits-1)
+		if got := windowLimit(q0, qBits, 1024); got != 1024 {
+			t.Errorf("期望 1024, 得到 %d", got)
+		}
+	})
+
+	t.Run("靠近顶端时截断", func(t *testing.T) {
+		q0 := new(big.Int).Sub(top, big.NewInt(100))
+		if got := windowLimit(q0, qBits, 1024); got != 100 {
+			t.Errorf("期望 100, 得到 %d", got)
+		}
+		// 截断后最后一个 delta 的候选仍然位长正确
+		c := buildCandidate(q0, 99)
+		if c.p.BitLen() != qBits+1 {
+			t.Errorf("p 应该是 %d 位, 得到 %d 位", qBits+1, c.p.BitLen())
+		}
+		if buildCandidate(q0, 100).p.BitLen() == qBits+1 {
+			t.Error("越过上界的候选位长应该不对")
+		}
+	})
+
+	t.Run("已越界", func(t *testing.T) {
+		q0 := new(big.Int).Add(top, big.NewInt(3))
+		if got := windowLimit(q0, qBits, 1024); got != 0 {
+			t.Errorf("期望 0, 得到 %d", got)
+		}
+	})
+}
+
+func TestGenerateSafePrime_AwkwardSize(t *testing.T) {
+	// 65 位：q 有 64 位，强制最高两位时 q0 常落在区间顶端附近
+	for _, setTopTwo := range []bool{true, false} {
+		t.Run(fmt.Sprintf("SetTopTwoBits=%v", setTopTwo), func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.SetTopTwoBits = setTopTwo
+			for i := 0; i < 100; i++ {
+				sp, err := GenerateSafePrime(65, cfg, rand.Reader)
+				if err != nil {
+					t.Fatalf("生成安全素数失败: %v", err)
+				}
+				if sp.P.BitLen() != 65 || sp.Q.BitLen() != 64 {
+					t.Fatalf("p 应该恰好 65 位, 得到 %d 位", sp.P.BitLen())
+				}
+			}
+		})
+	}
+
+	t.Run("顶端 q0 不产生位长错误的候选", func(t *testing.T) {
+		// 窗口内所有候选都不应被 bitLenFilter 拒绝
+		q0 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 64), big.NewInt(500))
+		normalizeMod3(q0)
+		limit := windowLimit(q0, 64, DefaultConfig().WindowDeltaMax)
+		lenOK := bitLenFilter(65)
+		rejected := 0
+		for delta := uint64(0); delta < limit; delta += 6 {
+			c := buildCandidate(q0, delta)
+			if !lenOK(&c) {
+				rejected++
+			}
+		}
+		if rejected != 0 {
+			t.Errorf("窗口内有 %d 个位长错误的候选", rejected)
+		}
+	})
+}
+
+func TestGenerateSafePrime_SeparateRounds(t *testing.T) {
+	t.Run("回退到 MillerRabinRounds", func(t *testing.T) {
+		cfg := &Config{MillerRabinRounds: 20}
+		if cfg.roundsQ() != 20 || cfg.roundsP() != 20 {
+			t.Errorf("期望 20/20, 得到 %d/%d", cfg.roundsQ(), cfg.roundsP())
+		}
+		cfg.MillerRabinRoundsQ = 40
+		cfg.MillerRabinRoundsP = 4
+		if cfg.roundsQ() != 40 || cfg.roundsP() != 4 {
+			t.Errorf("期望 40/4, 得到 %d/%d", cfg.roundsQ(), cfg.roundsP())
+		}
+	})
+
+	cases := []struct{ q, p int }{{40, 4}, {4, 40}, {1, 1}}
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("q=%d p=%d", tc.q, tc.p), func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.MillerRabinRoundsQ = tc.q
+			cfg.MillerRabinRoundsP = tc.p
+			sp, err := GenerateSafePrime(256, cfg, rand.Reader)
+			if err != nil {
+				t.Fatalf("生成安全素数失败: %v", err)
+			}
+			if !sp.IsValid() || VerifySophieGermain(sp.Q, 32) != nil {
+				t.Errorf("%v 不是安全素数", sp.P)
+			}
+		})
+	}
+}
+
+func TestConfigProfiles(t *testing.T) {
+	profiles := map[string]*Config{
+		"Fast":     FastConfig(),
+		"Balanced": BalancedConfig(),
+		"Paranoid": ParanoidConfig(),
+	}
+	for name, cfg := range profiles {
+		t.Run(name, func(t *testing.T) {
+			sp, err := GenerateSafePrime(256, cfg, rand.Reader)
+			if err != nil {
+				t.Fatalf("生成安全素数失败: %v", err)
+			}
+			if sp.P.BitLen() != 256 || !sp.IsValid() {
+				t.Errorf("应该得到有效的 256 位安全素数, 得到 %d 位", sp.P.BitLen())
+			}
+		})
+	}
+
+	t.Run("Balanced 等于默认配置", func(t *testing.T) {
+		if *BalancedConfig() != *DefaultConfig() {
+			t.Error("BalancedConfig 应该与 DefaultConfig 相同")
+		}
+	})
+
+	t.Run("Paranoid 字段", func(t *testing.T) {
+		cfg := ParanoidConfig()
+		if cfg.WindowDeltaMax <= DefaultConfig().WindowDeltaMax {
+			t.Error("Paranoid 的窗口应该大于默认值")
+		}
+		if cfg.MillerRabinRounds != 64 {
+			t.Errorf("Paranoid 应该使用 64 轮 Miller-Rabin, 得到 %d", cfg.MillerRabinRounds)
+		}
+		if !cfg.UseFermatQ || !cfg.UseFermatP {
+			t.Error("Paranoid 应该同时启用两个 Fermat 预筛")
+		}
+	})
+
+	t.Run("Fast 更轻量", func(t *testing.T) {
+		cfg := FastConfig()
+		if cfg.WindowDeltaMax >= DefaultConfig().WindowDeltaMax || cfg.MillerRabinRounds >= DefaultConfig().MillerRabinRounds {
+			t.Error("Fast 的窗口和轮数应该小于默认值")
+		}
+	})
+}
+
 // ================= 安全素数属性验证 =================
 
 func TestSafePrime_Properties(t *testing.T) {
@@ -295,3 +591,174 @@ func TestSafePrime_Properties(t *testing.T) {
 		}
 	})
 }
+
+func TestSafePrime_Helpers(t *testing.T) {
+	sp, err := GenerateSafePrime(256, nil, nil)
+	if err != nil {
+		t.Fatalf("生成安全素数失败: %v", err)
+	}
+
+	t.Run("SophieGermain 返回 Q", func(t *testing.T) {
+		if sp.SophieGermain().Cmp(sp.Q) != 0 {
+			t.Error("SophieGermain 应该返回 Q")
+		}
+	})
+
+	t.Run("有效的 SafePrime", func(t *testing.T) {
+		if !sp.IsValid() {
+			t.Error("生成的 SafePrime 应该有效")
+		}
+	})
+
+	t.Run("P != 2Q+1", func(t *testing.T) {
+		corrupted := &SafePrime{P: new(big.Int).Add(sp.P, bigTwo), Q: sp.Q}
+		if corrupted.IsValid() {
+			t.Error("P != 2Q+1 时不应该有效")
+		}
+	})
+
+	t.Run("Q 为偶数", func(t *testing.T) {
+		q := big.NewInt(10)
+		p := new(big.Int).Lsh(q, 1)
+		p.Add(p, bigOne)
+		corrupted := &SafePrime{P: p, Q: q}
+		if corrupted.IsValid() {
+			t.Error("Q 为偶数时不应该有效")
+		}
+	})
+
+	t.Run("nil 字段", func(t *testing.T) {
+		if (&SafePrime{P: sp.P}).IsValid() {
+			t.Error("Q 为 nil 时不应该有效")
+		}
+	})
+}
+
+func TestVerifySophieGermain(t *testing.T) {
+	sp, err := GenerateSafePrime(256, nil, nil)
+	if err != nil {
+		t.Fatalf("生成安全素数失败: %v", err)
+	}
+
+	t.Run("Sophie Germain 素数", func(t *testing.T) {
+		for _, q := range []*big.Int{big.NewInt(11), big.NewInt(1019), sp.Q} {
+			if err := VerifySophieGermain(q, 40); err != nil {
+				t.Errorf("q = %v 应该通过, 得到 %v", q, err)
+			}
+		}
+	})
+
+	t.Run("q 为素数但 2q+1 为合数", func(t *testing.T) {
+		// 13 -> 27 = 3^3, 7 -> 15 = 3*5
+		for _, q := range []*big.Int{big.NewInt(13), big.NewInt(7)} {
+			if err := VerifySophieGermain(q, 40); !errors.Is(err, ErrPComposite) {
+				t.Errorf("q = %v 应该返回 ErrPComposite, 得到 %v", q, err)
+			}
+		}
+	})
+
+	t.Run("q 为合数", func(t *testing.T) {
+		// 15 为合数，而 2*15+1 = 31 为素数，确认先检查 q
+		if err := VerifySophieGermain(big.NewInt(15), 40); !errors.Is(err, ErrQComposite) {
+			t.Errorf("应该返回 ErrQComposite, 得到 %v", err)
+		}
+	})
+
+	t.Run("非法参数", func(t *testing.T) {
+		if err := VerifySophieGermain(nil, 40); err == nil {
+			t.Error("应该返回错误当 q 为 nil")
+		}
+		if err := VerifySophieGermain(big.NewInt(11), 0); err == nil {
+			t.Error("应该返回错误当 rounds < 1")
+		}
+	})
+}
+
+// ================= Fermat 预筛测试 =================
+
+func TestVerifySafePrime(t *testing.T) {
+	reasonOf := func(err error) SafePrimeReason {
+		var spErr *SafePrimeError
+		if !errors.As(err, &spErr) {
+			t.Fatalf("应该返回 *SafePrimeError, 得到 %v", err)
+		}
+		return spErr.Reason
+	}
+
+	t.Run("有效安全素数", func(t *testing.T) {
+		sp := &SafePrime{P: big.NewInt(23), Q: big.NewInt(11)}
+		if err := VerifySafePrime(sp, 20); err != nil {
+			t.Errorf("23 = 2·11+1 应该通过, 得到 %v", err)
+		}
+	})
+
+	t.Run("关系不成立", func(t *testing.T) {
+		for _, sp := range []*SafePrime{
+			{P: big.NewInt(23), Q: big.NewInt(7)},
+			{P: big.NewInt(23)},
+			nil,
+		} {
+			if r := reasonOf(VerifySafePrime(sp, 20)); r != ReasonRelation {
+				t.Errorf("期望 ReasonRelation, 得到 %v", r)
+			}
+		}
+	})
+
+	t.Run("q 为合数", func(t *testing.T) {
+		err := VerifySafePrime(&SafePrime{P: big.NewInt(31), Q: big.NewInt(15)}, 20)
+		if r := reasonOf(err); r != ReasonQComposite {
+			t.Errorf("期望 ReasonQComposite, 得到 %v", r)
+		}
+		if !errors.Is(err, ErrQComposite) {
+			t.Error("应该能用 errors.Is 匹配 ErrQComposite")
+		}
+	})
+
+	t.Run("p 为合数", func(t *testing.T) {
+		// 13 是素数，但 27 = 3^3
+		err := VerifySafePrime(&SafePrime{P: big.NewInt(27), Q: big.NewInt(13)}, 20)
+		if r := reasonOf(err); r != ReasonPComposite {
+			t.Errorf("期望 ReasonPComposite, 得到 %v", r)
+		}
+		if !errors.Is(err, ErrPComposite) {
+			t.Error("应该能用 errors.Is 匹配 ErrPComposite")
+		}
+	})
+
+	t.Run("rounds 非法", func(t *testing.T) {
+		if err := VerifySafePrime(&SafePrime{P: big.NewInt(23), Q: big.NewInt(11)}, 0); err == nil {
+			t.Error("应该返回错误当 rounds < 1")
+		}
+	})
+}
+
+func TestFermatBase2(t *testing.T) {
+	t.Run("素数通过", func(t *testing.T) {
+		for _, p := range []int64{2, 3, 5, 7, 11, 101, 7919} {
+			if !FermatBase2(big.NewInt(p)) {
+				t.Errorf("素数 %d 应该通过", p)
+			}
+		}
+		m127 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 127), big.NewInt(1))
+		if !FermatBase2(m127) {
+			t.Error("2^127 - 1 应该通过")
+		}
+	})
+
+	t.Run("Fermat 伪素数通过", func(t *testing.T) {
+		// 341 = 11·31、561 = 3·11·17、645 = 3·5·43 都是以 2 为底的伪素数
+		for _, n := range []int64{341, 561, 645} {
+			if !FermatBase2(big.NewInt(n)) {
+				t.Errorf("伪素数 %d 应该通过（预筛不能排除它）", n)
+			}
+		}
+	})
+
+	t.Run("合数被拒绝", func(t *testing.T) {
+		for _, n := range []int64{0, 1, 4, 9, 15, 91, 221, 1001} {
+			if FermatBase2(big.NewInt(n)) {
+				t.Errorf("%d 不应该通过", n)
+			}
+		}
+	})
+}
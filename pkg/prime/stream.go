@@ -0,0 +1,41 @@
+package prime
+
+import (
+	"context"
+	"io"
+)
+
+// ================= 流式生成 =================
+
+// SafePrimeResult 是 SafePrimeStream 的一次输出：Prime 与 Err 二选一
+type SafePrimeResult struct {
+	Prime *SafePrime
+	Err   error
+}
+
+// SafePrimeStream 在后台 goroutine 中持续生成 bits 位的安全素数，逐个发送到返回的 channel，
+// 直到 ctx 被取消。适合预先生成素数池的场景。
+//
+//   - 单次生成本身不可中断：ctx 取消后，goroutine 在当前这次生成结束时退出；
+//   - 生成出错时发送一个带 Err 的结果后停止（参数错误等会反复出现，继续生成没有意义）；
+//   - 无论哪种方式结束，channel 都会被关闭，调用方 range 读取即可，不会泄漏 goroutine。
+//
+// r 只在后台 goroutine 中使用，调用方不应同时从其他 goroutine 读取它。
+func SafePrimeStream(ctx context.Context, bits int, cfg *Config, r io.Reader) <-chan SafePrimeResult {
+	ch := make(chan SafePrimeResult)
+	go func() {
+		defer close(ch)
+		for ctx.Err() == nil {
+			sp, err := GenerateSafePrime(bits, cfg, r)
+			select {
+			case ch <- SafePrimeResult{Prime: sp, Err: err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return ch
+}
@@ -0,0 +1,67 @@
+package prime
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestSafePrimeStream(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	t.Run("取三个后取消", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		stream := SafePrimeStream(ctx, 256, nil, nil)
+
+		seen := make(map[string]bool)
+		for i := 0; i < 3; i++ {
+			res, ok := <-stream
+			if !ok {
+				t.Fatal("channel 不应该提前关闭")
+			}
+			if res.Err != nil {
+				t.Fatalf("生成失败: %v", res.Err)
+			}
+			verifySafePrime(t, res.Prime, 256)
+			if seen[res.Prime.P.String()] {
+				t.Error("流中出现了重复的素数")
+			}
+			seen[res.Prime.P.String()] = true
+		}
+
+		cancel()
+		// 取消后 channel 必须在有限时间内关闭
+		timeout := time.After(10 * time.Second)
+		for {
+			select {
+			case _, ok := <-stream:
+				if !ok {
+					return
+				}
+			case <-timeout:
+				t.Fatal("取消后 channel 没有关闭")
+			}
+		}
+	})
+
+	t.Run("参数错误时发送错误并关闭", func(t *testing.T) {
+		stream := SafePrimeStream(context.Background(), 2, nil, nil)
+		res, ok := <-stream
+		if !ok || res.Err == nil {
+			t.Fatal("应该收到一个错误结果")
+		}
+		if _, ok := <-stream; ok {
+			t.Error("错误之后 channel 应该关闭")
+		}
+	})
+
+	// 无 goroutine 泄漏：子测试都结束后，数量应回落到初始值
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Errorf("goroutine 数量从 %d 增加到 %d", before, n)
+	}
+}
@@ -0,0 +1,60 @@
+// Package shareenc 把 VSS 份额与 Paillier 加密粘合起来：
+// DKG 中 dealer 在广播前用各参与方的 Paillier 公钥加密其份额，只有对应私钥的持有者能解出 f(x_i)。
+package shareenc
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"tss-crypto/pkg/paillier"
+	"tss-crypto/pkg/vss"
+)
+
+// ---- 错误 ----
+
+// 可用 errors.Is 判断的错误类型
+var (
+	ErrMissingKey     = errors.New("no paillier public key for share index")
+	ErrInvalidShare   = errors.New("invalid share")
+	ErrDuplicateIndex = errors.New("duplicate share index")
+)
+
+// ---- 公开 API ----
+
+// IndexKey 返回份额下标在 EncryptShares 的 pubs 与返回值中使用的键：x_i 的十进制表示
+func IndexKey(index vss.Index) string {
+	return index.String()
+}
+
+// EncryptShares 用 pubs[IndexKey(x_i)] 加密每个份额的值 f(x_i)，返回以同样的键索引的密文。
+// 任一份额缺少对应公钥、份额为 nil 或下标重复时返回错误，不会只加密一部分；
+// r 为 nil 时使用 crypto/rand
+func EncryptShares(shares vss.Shares, pubs map[string]*paillier.PublicKey, r io.Reader) (map[string]*big.Int, error) {
+	if r == nil {
+		r = rand.Reader
+	}
+
+	out := make(map[string]*big.Int, len(shares))
+	for i, share := range shares {
+		if share == nil || share.Index == nil || share.Value == nil {
+			return nil, fmt.Errorf("%w: share %d is nil", ErrInvalidShare, i)
+		}
+		key := IndexKey(share.Index)
+		if _, dup := out[key]; dup {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateIndex, key)
+		}
+		pub, ok := pubs[key]
+		if !ok || pub == nil {
+			return nil, fmt.Errorf("%w: %s", ErrMissingKey, key)
+		}
+		c, err := pub.Encrypt(r, share.Value)
+		if err != nil {
+			return nil, fmt.Errorf("encrypt share %s: %w", key, err)
+		}
+		out[key] = c
+	}
+	return out, nil
+}
@@ -0,0 +1,126 @@
+package shareenc
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+
+	"tss-crypto/pkg/paillier"
+	"tss-crypto/pkg/vss"
+)
+
+// testKeyBits 是测试用 Paillier 密钥的位数（不安全，仅为加快测试）
+const testKeyBits = 512
+
+func TestEncryptShares(t *testing.T) {
+	curve := elliptic.P256()
+	const n, threshold = 4, 3
+	indices, err := vss.SequentialIndices(curve, n)
+	if err != nil {
+		t.Fatalf("SequentialIndices 失败: %v", err)
+	}
+	secret, err := rand.Int(rand.Reader, curve.Params().N)
+	if err != nil {
+		t.Fatalf("生成秘密失败: %v", err)
+	}
+	commit, shares, err := vss.SplitSecret(curve, threshold, secret, indices)
+	if err != nil {
+		t.Fatalf("SplitSecret 失败: %v", err)
+	}
+
+	privs := make(map[string]*paillier.PrivateKey, n)
+	pubs := make(map[string]*paillier.PublicKey, n)
+	for _, idx := range indices {
+		priv, err := paillier.GenerateKeyInsecure(rand.Reader, testKeyBits)
+		if err != nil {
+			t.Fatalf("生成密钥失败: %v", err)
+		}
+		privs[IndexKey(idx)] = priv
+		pubs[IndexKey(idx)] = priv.Public()
+	}
+
+	t.Run("加密后解密并对承诺验证", func(t *testing.T) {
+		enc, err := EncryptShares(shares, pubs, rand.Reader)
+		if err != nil {
+			t.Fatalf("EncryptShares 失败: %v", err)
+		}
+		if len(enc) != n {
+			t.Fatalf("应该得到 %d 个密文, 得到 %d", n, len(enc))
+		}
+
+		recovered := make(vss.Shares, 0, n)
+		for _, share := range shares {
+			key := IndexKey(share.Index)
+			c, ok := enc[key]
+			if !ok {
+				t.Fatalf("缺少下标 %s 的密文", key)
+			}
+			if c.Cmp(share.Value) == 0 {
+				t.Error("密文不应该等于明文份额")
+			}
+			m, err := privs[key].Decrypt(c)
+			if err != nil {
+				t.Fatalf("解密失败: %v", err)
+			}
+			got := &vss.Share{Index: share.Index, Value: m, Threshold: share.Threshold}
+			if !got.Verify(curve, commit) {
+				t.Errorf("下标 %s 解密出的份额未通过承诺验证", key)
+			}
+			recovered = append(recovered, got)
+		}
+
+		s, err := vss.Reconstruct(curve, threshold, recovered[:threshold])
+		if err != nil {
+			t.Fatalf("Reconstruct 失败: %v", err)
+		}
+		if s.Cmp(secret) != 0 {
+			t.Error("由解密份额重构的秘密不正确")
+		}
+	})
+
+	t.Run("其他参与方的私钥无法解出份额", func(t *testing.T) {
+		enc, err := EncryptShares(shares, pubs, nil)
+		if err != nil {
+			t.Fatalf("EncryptShares 失败: %v", err)
+		}
+		k0, k1 := IndexKey(indices[0]), IndexKey(indices[1])
+		m, err := privs[k1].Decrypt(enc[k0])
+		if err == nil && m.Cmp(shares[0].Value) == 0 {
+			t.Error("错误的私钥不应该解出份额")
+		}
+	})
+
+	t.Run("缺少公钥", func(t *testing.T) {
+		partial := make(map[string]*paillier.PublicKey, n)
+		for k, v := range pubs {
+			partial[k] = v
+		}
+		delete(partial, IndexKey(indices[2]))
+		if _, err := EncryptShares(shares, partial, rand.Reader); !errors.Is(err, ErrMissingKey) {
+			t.Errorf("应该返回 ErrMissingKey, 得到 %v", err)
+		}
+	})
+
+	t.Run("重复下标", func(t *testing.T) {
+		dup := vss.Shares{shares[0], shares[0]}
+		if _, err := EncryptShares(dup, pubs, rand.Reader); !errors.Is(err, ErrDuplicateIndex) {
+			t.Errorf("应该返回 ErrDuplicateIndex, 得到 %v", err)
+		}
+	})
+
+	t.Run("nil 份额", func(t *testing.T) {
+		if _, err := EncryptShares(vss.Shares{nil}, pubs, rand.Reader); !errors.Is(err, ErrInvalidShare) {
+			t.Errorf("应该返回 ErrInvalidShare, 得到 %v", err)
+		}
+	})
+
+	t.Run("份额值超出明文空间", func(t *testing.T) {
+		key := IndexKey(indices[0])
+		tooLarge := &vss.Share{Index: indices[0], Value: new(big.Int).Set(pubs[key].N), Threshold: threshold}
+		if _, err := EncryptShares(vss.Shares{tooLarge}, pubs, rand.Reader); err == nil {
+			t.Error("应该返回错误当份额值 >= N")
+		}
+	})
+}
@@ -0,0 +1,75 @@
+// Package transcript 提供 Fiat-Shamir 变换使用的证明记录（transcript）。
+//
+// 所有写入都带标签和长度前缀，不同协议用不同的 domain 区分，避免拼接歧义和跨协议重放。
+// 哈希函数可替换（默认 SHA-256），便于与使用 SHA-512、SHA3 等的实现互通。
+package transcript
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"math/big"
+)
+
+// Transcript 累积协议消息并从中派生挑战值，不可并发使用
+type Transcript struct {
+	newHash func() hash.Hash
+	state   hash.Hash
+}
+
+// New 创建使用 SHA-256 的 transcript，domain 用于协议间的域分离
+func New(domain string) *Transcript {
+	return NewWithHash(domain, sha256.New)
+}
+
+// NewWithHash 创建使用指定哈希函数的 transcript
+func NewWithHash(domain string, newHash func() hash.Hash) *Transcript {
+	t := &Transcript{newHash: newHash, state: newHash()}
+	t.Append("domain", []byte(domain))
+	return t
+}
+
+// Append 写入一条带标签的消息：len(label) || label || len(data) || data
+func (t *Transcript) Append(label string, data []byte) {
+	writeWithLength(t.state, []byte(label))
+	writeWithLength(t.state, data)
+}
+
+// AppendInt 写入一个非负大整数（大端字节）
+func (t *Transcript) AppendInt(label string, x *big.Int) {
+	t.Append(label, x.Bytes())
+}
+
+// Challenge 从当前状态派生 [0, mod) 内的挑战值。
+// 以计数器模式扩展出比 mod 多 16 字节的输出再取模，取模偏差可忽略；
+// 挑战本身随后写回 transcript，使后续挑战依赖于它
+func (t *Transcript) Challenge(label string, mod *big.Int) *big.Int {
+	writeWithLength(t.state, []byte("challenge"))
+	writeWithLength(t.state, []byte(label))
+	seed := t.state.Sum(nil)
+
+	need := (mod.BitLen()+7)/8 + 16
+	out := make([]byte, 0, need)
+	for counter := uint32(0); len(out) < need; counter++ {
+		h := t.newHash()
+		h.Write(seed)
+		var c [4]byte
+		binary.BigEndian.PutUint32(c[:], counter)
+		h.Write(c[:])
+		out = h.Sum(out)
+	}
+	out = out[:need]
+
+	e := new(big.Int).SetBytes(out)
+	e.Mod(e, mod)
+	t.AppendInt(label, e)
+	return e
+}
+
+// writeWithLength 写入 8 字节大端长度前缀和数据
+func writeWithLength(h hash.Hash, data []byte) {
+	var l [8]byte
+	binary.BigEndian.PutUint64(l[:], uint64(len(data)))
+	h.Write(l[:])
+	h.Write(data)
+}
@@ -0,0 +1,96 @@
+package transcript
+
+import (
+	"crypto/sha3"
+	"crypto/sha512"
+	"hash"
+	"math/big"
+	"testing"
+)
+
+func TestTranscript(t *testing.T) {
+	q := new(big.Int).Lsh(big.NewInt(1), 256)
+
+	build := func(domain, label string) *Transcript {
+		tr := New(domain)
+		tr.Append("N", []byte{0x01, 0x02, 0x03})
+		tr.AppendInt(label, big.NewInt(123456))
+		return tr
+	}
+
+	t.Run("相同写入得到相同挑战", func(t *testing.T) {
+		a := build("test", "x").Challenge("e", q)
+		b := build("test", "x").Challenge("e", q)
+		if a.Cmp(b) != 0 {
+			t.Error("相同写入应该得到相同挑战")
+		}
+		if a.Sign() < 0 || a.Cmp(q) >= 0 {
+			t.Errorf("挑战 %v 应该在 [0, q) 内", a)
+		}
+	})
+
+	t.Run("标签或域改变挑战", func(t *testing.T) {
+		base := build("test", "x").Challenge("e", q)
+		if build("test", "y").Challenge("e", q).Cmp(base) == 0 {
+			t.Error("写入标签不同时挑战应该不同")
+		}
+		if build("test", "x").Challenge("f", q).Cmp(base) == 0 {
+			t.Error("挑战标签不同时挑战应该不同")
+		}
+		if build("other", "x").Challenge("e", q).Cmp(base) == 0 {
+			t.Error("domain 不同时挑战应该不同")
+		}
+	})
+
+	t.Run("长度前缀消除拼接歧义", func(t *testing.T) {
+		a := New("test")
+		a.Append("m", []byte("ab"))
+		a.Append("m", []byte("c"))
+		b := New("test")
+		b.Append("m", []byte("a"))
+		b.Append("m", []byte("bc"))
+		if a.Challenge("e", q).Cmp(b.Challenge("e", q)) == 0 {
+			t.Error("不同的消息切分应该得到不同挑战")
+		}
+	})
+
+	t.Run("连续挑战互不相同", func(t *testing.T) {
+		tr := build("test", "x")
+		if tr.Challenge("e", q).Cmp(tr.Challenge("e", q)) == 0 {
+			t.Error("第二个挑战应该依赖第一个挑战")
+		}
+	})
+
+	t.Run("可替换哈希", func(t *testing.T) {
+		hashes := map[string]func() hash.Hash{
+			"SHA-512":  sha512.New,
+			"SHA3-256": func() hash.Hash { return sha3.New256() },
+		}
+		base := build("test", "x").Challenge("e", q)
+		for name, h := range hashes {
+			mk := func() *big.Int {
+				tr := NewWithHash("test", h)
+				tr.Append("N", []byte{0x01, 0x02, 0x03})
+				tr.AppendInt("x", big.NewInt(123456))
+				return tr.Challenge("e", q)
+			}
+			a, b := mk(), mk()
+			if a.Cmp(b) != 0 {
+				t.Errorf("%s: 相同写入应该得到相同挑战", name)
+			}
+			if a.Cmp(base) == 0 {
+				t.Errorf("%s: 挑战不应该与 SHA-256 相同", name)
+			}
+		}
+	})
+
+	t.Run("小模数", func(t *testing.T) {
+		m := big.NewInt(7)
+		for i := 0; i < 20; i++ {
+			e := build("test", "x").Challenge(string(rune('a'+i)), m)
+			if e.Sign() < 0 || e.Cmp(m) >= 0 {
+				t.Fatalf("挑战 %v 应该在 [0, 7) 内", e)
+			}
+		}
+	})
+}
@@ -0,0 +1,115 @@
+package vss
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+
+	"tss-crypto/pkg/ec"
+	"tss-crypto/pkg/mod"
+)
+
+// ---- 跨 dealer 批量验证 ----
+
+// batchWeightBits 是随机权重 ρ_d 的位数，坏份额通过聚合检查的概率不超过 2^-batchWeightBits
+const batchWeightBits = 128
+
+// DealerShare 是某个 dealer 发给本方的份额及该 dealer 广播的承诺
+type DealerShare struct {
+	Commitment *Commitment
+	Share      *Share
+}
+
+// BatchVerifyAcrossDealers 把来自多个 dealer 的份额验证合并成一个随机化的聚合等式：
+//
+//	(Σ_d ρ_d·s_d)·G == Σ_d Σ_j (ρ_d·x_d^j)·C_{d,j}
+//
+// 其中 ρ_d 为随机权重。聚合检查只需一次基点乘法与一次比较；
+// 不通过时再逐个 Share.Verify 找出作恶的 dealer。
+// 返回是否全部通过，以及失败条目在 entries 中的下标（升序）
+func BatchVerifyAcrossDealers(curve elliptic.Curve, entries []DealerShare) (bool, []int) {
+	var failed []int
+	valid := make([]int, 0, len(entries))
+	for i, e := range entries {
+		if curve == nil || !wellFormed(curve, e) {
+			failed = append(failed, i)
+			continue
+		}
+		valid = append(valid, i)
+	}
+	if len(valid) == 0 || aggregateHolds(curve, entries, valid) {
+		return len(failed) == 0, failed
+	}
+
+	// 聚合等式不成立：逐个验证定位失败的 dealer
+	var bad []int
+	for _, i := range valid {
+		if !entries[i].Share.Verify(curve, entries[i].Commitment) {
+			bad = append(bad, i)
+		}
+	}
+	return false, mergeSorted(failed, bad)
+}
+
+// wellFormed 检查条目的结构：份额字段齐全、承诺合法、曲线与门限一致
+func wellFormed(curve elliptic.Curve, e DealerShare) bool {
+	s := e.Share
+	if s == nil || s.Index == nil || s.Value == nil {
+		return false
+	}
+	c := e.Commitment
+	if c.Validate() != nil || !ec.SameCurve(c.Curve, curve) || s.Threshold != c.Degree() {
+		return false
+	}
+	return true
+}
+
+// aggregateHolds 对 valid 中的条目计算随机化聚合等式；取随机数失败时按不成立处理，交由逐个验证
+func aggregateHolds(curve elliptic.Curve, entries []DealerShare, valid []int) bool {
+	N := curve.Params().N
+	field := ec.NewScalarField(curve)
+	bound := new(big.Int).Lsh(big.NewInt(1), batchWeightBits)
+
+	lhs := new(big.Int)
+	var rhs *ec.Point
+	for _, i := range valid {
+		rho, err := rand.Int(rand.Reader, bound)
+		if err != nil {
+			return false
+		}
+		rho.Add(rho, big.NewInt(1)) // ρ ∈ [1, 2^128]
+
+		s := entries[i].Share
+		lhs = field.Add(lhs, field.Mul(rho, s.Value))
+
+		// exp 依次为 ρ·x^0, ρ·x^1, ...
+		exp := mod.Mod(rho, N)
+		for _, C := range entries[i].Commitment.Coeffs {
+			term := C.ScalarMult(exp)
+			if rhs == nil {
+				rhs = term
+			} else {
+				rhs = rhs.Add(term)
+			}
+			exp = field.Mul(exp, s.Index)
+		}
+	}
+	return ec.ScalarBaseMult(curve, lhs).Equal(rhs)
+}
+
+// mergeSorted 合并两个升序下标切片
+func mergeSorted(a, b []int) []int {
+	out := make([]int, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if a[i] < b[j] {
+			out = append(out, a[i])
+			i++
+		} else {
+			out = append(out, b[j])
+			j++
+		}
+	}
+	out = append(out, a[i:]...)
+	return append(out, b[j:]...)
+}
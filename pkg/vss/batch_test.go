@@ -0,0 +1,62 @@
+package vss
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestBatchVerifyAcrossDealers(t *testing.T) {
+	curve := elliptic.P256()
+	indices, _ := SequentialIndices(curve, 4)
+
+	// 3 个 dealer 各自分享，取发给下标 2 的份额
+	entries := make([]DealerShare, 3)
+	for d := range entries {
+		commit, shares, err := SplitSecret(curve, 3, big.NewInt(int64(100+d)), indices)
+		if err != nil {
+			t.Fatalf("SplitSecret 失败: %v", err)
+		}
+		entries[d] = DealerShare{Commitment: commit, Share: shares[1]}
+	}
+
+	t.Run("全部有效", func(t *testing.T) {
+		ok, failed := BatchVerifyAcrossDealers(curve, entries)
+		if !ok || len(failed) != 0 {
+			t.Errorf("应该全部通过, 得到 %v, %v", ok, failed)
+		}
+	})
+
+	t.Run("只标记发送坏份额的 dealer", func(t *testing.T) {
+		bad := append([]DealerShare{}, entries...)
+		s := *bad[1].Share
+		s.Value = new(big.Int).Add(s.Value, big.NewInt(1))
+		bad[1].Share = &s
+
+		ok, failed := BatchVerifyAcrossDealers(curve, bad)
+		if ok {
+			t.Error("含坏份额时不应该通过")
+		}
+		if !reflect.DeepEqual(failed, []int{1}) {
+			t.Errorf("应该只标记 dealer 1, 得到 %v", failed)
+		}
+	})
+
+	t.Run("结构错误的条目", func(t *testing.T) {
+		bad := append([]DealerShare{}, entries...)
+		bad[0].Share = nil
+		bad[2].Commitment = &Commitment{Curve: curve}
+
+		ok, failed := BatchVerifyAcrossDealers(curve, bad)
+		if ok || !reflect.DeepEqual(failed, []int{0, 2}) {
+			t.Errorf("应该标记 dealer 0 和 2, 得到 %v, %v", ok, failed)
+		}
+	})
+
+	t.Run("空输入", func(t *testing.T) {
+		if ok, failed := BatchVerifyAcrossDealers(curve, nil); !ok || len(failed) != 0 {
+			t.Errorf("空输入应该通过, 得到 %v, %v", ok, failed)
+		}
+	})
+}
@@ -0,0 +1,223 @@
+package vss
+
+import (
+	"crypto/elliptic"
+	"fmt"
+	"math/big"
+
+	"tss-crypto/pkg/ec"
+)
+
+// ---- 增量构造 ----
+
+// CommitmentBuilder 按系数到达的顺序逐个累积 Feldman 承诺 C_j = a_j·G，
+// 调用方可以边生成多项式边提交，无需同时持有全部系数。
+// 第一个加入的系数是 a_0（即 secret），之后依次为 a_1, a_2, ...
+type CommitmentBuilder struct {
+	curve  elliptic.Curve
+	table  *ec.BaseMultTable
+	coeffs []*ec.Point
+	err    error
+}
+
+// NewCommitmentBuilder 创建 curve 上的空承诺构造器
+func NewCommitmentBuilder(curve elliptic.Curve) *CommitmentBuilder {
+	b := &CommitmentBuilder{curve: curve}
+	if curve == nil {
+		b.err = ErrNilCurve
+		return b
+	}
+	b.table = ec.NewBaseMultTable(curve)
+	return b
+}
+
+// AddCoefficient 追加下一个系数 a（按 mod N 解释）的承诺 a·G。
+// a 为 nil 时记录错误，之后 Build 返回 nil
+func (b *CommitmentBuilder) AddCoefficient(a *big.Int) {
+	if b.err != nil {
+		return
+	}
+	if a == nil {
+		b.err = fmt.Errorf("polynomial coefficient %d is nil", len(b.coeffs))
+		return
+	}
+	b.coeffs = append(b.coeffs, b.table.Mult(a))
+}
+
+// Err 返回构造过程中记录的第一个错误
+func (b *CommitmentBuilder) Err() error {
+	if b.err == nil && len(b.coeffs) == 0 {
+		return ErrThresholdTooSmall
+	}
+	return b.err
+}
+
+// Build 返回目前为止累积的承诺，门限为已加入的系数个数。
+// 没有系数或构造出错时返回 nil（原因见 Err）；返回的承诺不与构造器共享系数切片
+func (b *CommitmentBuilder) Build() *Commitment {
+	if b.Err() != nil {
+		return nil
+	}
+	coeffs := make([]*ec.Point, len(b.coeffs))
+	copy(coeffs, b.coeffs)
+	return &Commitment{Curve: b.curve, Coeffs: coeffs}
+}
+
+// ---- 承诺运算 ----
+
+// Degree 返回承诺的系数个数 len(Coeffs)，即门限 t（多项式次数为 t-1）
+func (c *Commitment) Degree() int {
+	if c == nil {
+		return 0
+	}
+	return len(c.Coeffs)
+}
+
+// Validate 检查承诺结构是否完整：曲线非空、至少一个系数，且每个系数点非 nil、
+// 属于承诺的曲线（按 ec.SameCurve 比较参数）并在曲线上（无穷远点按 ec.Point.IsInfinity 判断）。
+// 用于在验证份额前拒绝反序列化得到的畸形承诺，错误包装 ErrInvalidCommitment
+func (c *Commitment) Validate() error {
+	if c == nil || c.Curve == nil {
+		return fmt.Errorf("commitment or curve is nil: %w", ErrInvalidCommitment)
+	}
+	if len(c.Coeffs) == 0 {
+		return fmt.Errorf("commitment has no coefficients: %w", ErrInvalidCommitment)
+	}
+	for i, pt := range c.Coeffs {
+		if pt == nil || pt.X == nil || pt.Y == nil {
+			return fmt.Errorf("coefficient %d is nil: %w", i, ErrInvalidCommitment)
+		}
+		if !ec.SameCurve(pt.Curve, c.Curve) {
+			return fmt.Errorf("coefficient %d: curve mismatch: %w", i, ErrInvalidCommitment)
+		}
+		if !pt.IsInfinity() && !pt.IsOnCurve() {
+			return fmt.Errorf("coefficient %d is not on curve: %w", i, ErrInvalidCommitment)
+		}
+	}
+	return nil
+}
+
+// HasDegree 判断承诺是否恰好对应门限 threshold（t-1 次多项式）且结构有效：
+// len(Coeffs) == threshold 并通过 Validate。用于在收到任何份额之前拒绝长度错误的承诺
+func (c *Commitment) HasDegree(threshold int) bool {
+	if threshold < 1 || c.Degree() != threshold {
+		return false
+	}
+	return c.Validate() == nil
+}
+
+// Equal 判断两个承诺是否相同：同一曲线（按 ec.SameCurve 比较参数）、相同次数、逐个系数点相等
+func (c *Commitment) Equal(other *Commitment) bool {
+	if c == nil || other == nil {
+		return c == other
+	}
+	if !ec.SameCurve(c.Curve, other.Curve) || len(c.Coeffs) != len(other.Coeffs) {
+		return false
+	}
+	for i := range c.Coeffs {
+		if !c.Coeffs[i].Equal(other.Coeffs[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluate 计算承诺多项式在 index 处的点值 Σ C_j·index^j，即 f(index)·G
+func (c *Commitment) evaluate(index Index) *ec.Point {
+	field := ec.NewScalarField(c.Curve)
+
+	// 累加承诺多项式的点值：result = C_0
+	result := c.Coeffs[0].Copy()
+
+	// exp = index，后续exp依次乘index得到 index^2, index^3, ...
+	exp := new(big.Int).Set(index)
+	for _, pt := range c.Coeffs[1:] {
+		// 累加 C_j * index^j
+		result = result.Add(pt.ScalarMult(exp))
+		// exp = exp * index mod N，得到下一个index的幂
+		exp = field.Mul(exp, index)
+	}
+	return result
+}
+
+// VerifyCached 与 Verify 结果相同，但把承诺在该下标处的求值 Σ C_j·index^j 缓存在 commit 上，
+// 之后对同一下标的验证（例如多轮协议中反复校验同一份额）只需一次基点乘法。
+// 缓存以规范化下标为键，可并发使用；缓存后修改 commit.Coeffs 须调用 ResetCache
+func (s *Share) VerifyCached(curve elliptic.Curve, commit *Commitment) bool {
+	if s == nil || commit == nil ||
+		s.Index == nil || s.Value == nil ||
+		s.Threshold < 1 || s.Threshold != commit.Degree() {
+		return false
+	}
+	if !ec.SameCurve(curve, commit.Curve) {
+		return false
+	}
+	return commit.evaluateCached(s.Index).Equal(ec.ScalarBaseMult(curve, s.Value))
+}
+
+// ResetCache 清空 VerifyCached 的缓存
+func (c *Commitment) ResetCache() {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	c.cache = nil
+}
+
+// evaluateCached 返回缓存的 evaluate(index)，没有时计算并写入缓存
+func (c *Commitment) evaluateCached(index Index) *ec.Point {
+	key := new(big.Int).Mod(index, c.Curve.Params().N).String()
+
+	c.cacheMu.Lock()
+	pt, ok := c.cache[key]
+	c.cacheMu.Unlock()
+	if ok {
+		return pt
+	}
+
+	// 求值不持锁，并发时可能重复计算，结果相同
+	pt = c.evaluate(index)
+	c.cacheMu.Lock()
+	if c.cache == nil {
+		c.cache = make(map[string]*ec.Point)
+	}
+	c.cache[key] = pt
+	c.cacheMu.Unlock()
+	return pt
+}
+
+// MergeCommitments 将多个 dealer 的承诺逐系数相加：C_j = Σ C_j^{(d)}。
+// 承诺的同态性保证：各 dealer 在同一下标发出的份额之和，能通过合并后承诺的验证。
+// 所有承诺必须通过 Validate、在同一曲线上且次数相同，否则返回包装 ErrInvalidCommitment 的错误。
+// 承诺通常来自对端，先校验可避免畸形的系数点在点加时引发 panic
+func MergeCommitments(commits []*Commitment) (*Commitment, error) {
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("no commitments to merge: %w", ErrInvalidCommitment)
+	}
+	for i, c := range commits {
+		if err := c.Validate(); err != nil {
+			return nil, fmt.Errorf("commitment %d: %w", i, err)
+		}
+	}
+	first := commits[0]
+
+	merged := &Commitment{
+		Curve:  first.Curve,
+		Coeffs: make([]*ec.Point, len(first.Coeffs)),
+	}
+	for j, pt := range first.Coeffs {
+		merged.Coeffs[j] = pt.Copy()
+	}
+
+	for i, c := range commits[1:] {
+		if !ec.SameCurve(c.Curve, first.Curve) {
+			return nil, fmt.Errorf("commitment %d: curve mismatch: %w", i+1, ErrInvalidCommitment)
+		}
+		if len(c.Coeffs) != len(first.Coeffs) {
+			return nil, fmt.Errorf("commitment %d: degree mismatch: %d coefficients, expected %d: %w",
+				i+1, len(c.Coeffs), len(first.Coeffs), ErrInvalidCommitment)
+		}
+		for j, pt := range c.Coeffs {
+			merged.Coeffs[j] = merged.Coeffs[j].Add(pt)
+		}
+	}
+	return merged, nil
+}
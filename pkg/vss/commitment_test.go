@@ -0,0 +1,382 @@
+package vss
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+	"testing"
+
+	"tss-crypto/pkg/ec"
+	"tss-crypto/pkg/mod"
+)
+
+func TestMergeCommitments(t *testing.T) {
+	curve := elliptic.P256()
+	N := curve.Params().N
+	indices, _ := SequentialIndices(curve, 4)
+
+	commitA, sharesA, err := SplitSecret(curve, 3, big.NewInt(111), indices)
+	if err != nil {
+		t.Fatalf("SplitSecret 失败: %v", err)
+	}
+	commitB, sharesB, err := SplitSecret(curve, 3, big.NewInt(222), indices)
+	if err != nil {
+		t.Fatalf("SplitSecret 失败: %v", err)
+	}
+
+	merged, err := MergeCommitments([]*Commitment{commitA, commitB})
+	if err != nil {
+		t.Fatalf("MergeCommitments 失败: %v", err)
+	}
+
+	t.Run("份额之和通过合并承诺验证", func(t *testing.T) {
+		for i := range indices {
+			sum := &Share{
+				Index:     sharesA[i].Index,
+				Value:     mod.ModAdd(sharesA[i].Value, sharesB[i].Value, N),
+				Threshold: 3,
+			}
+			if !sum.Verify(curve, merged) {
+				t.Errorf("下标 %v 的份额之和验证失败", sum.Index)
+			}
+			// 单个 dealer 的份额不应通过合并承诺
+			if sharesA[i].Verify(curve, merged) {
+				t.Errorf("下标 %v 的单个份额不应该通过合并承诺", sum.Index)
+			}
+		}
+	})
+
+	t.Run("合并后 C_0 对应秘密之和", func(t *testing.T) {
+		commitSum, _, _ := SplitSecret(curve, 3, big.NewInt(333), indices)
+		if !merged.Coeffs[0].Equal(commitSum.Coeffs[0]) {
+			t.Error("合并后的 C_0 应该等于 333·G")
+		}
+	})
+
+	t.Run("Equal", func(t *testing.T) {
+		again, _ := MergeCommitments([]*Commitment{commitB, commitA})
+		if !merged.Equal(again) {
+			t.Error("合并结果应该与顺序无关")
+		}
+		if merged.Equal(commitA) {
+			t.Error("不同承诺不应该相等")
+		}
+		if !commitA.Equal(commitA) {
+			t.Error("承诺应该与自身相等")
+		}
+		var nilCommit *Commitment
+		if merged.Equal(nilCommit) || !nilCommit.Equal(nil) {
+			t.Error("nil 承诺只应该与 nil 相等")
+		}
+	})
+
+	t.Run("次数或曲线不一致", func(t *testing.T) {
+		short, _, _ := SplitSecret(curve, 2, big.NewInt(1), indices)
+		if _, err := MergeCommitments([]*Commitment{commitA, short}); !errors.Is(err, ErrInvalidCommitment) {
+			t.Errorf("次数不一致时应该返回 ErrInvalidCommitment, 得到 %v", err)
+		}
+		other, _, _ := SplitSecret(elliptic.P384(), 3, big.NewInt(1), indices)
+		if _, err := MergeCommitments([]*Commitment{commitA, other}); !errors.Is(err, ErrInvalidCommitment) {
+			t.Errorf("曲线不一致时应该返回 ErrInvalidCommitment, 得到 %v", err)
+		}
+		if _, err := MergeCommitments(nil); !errors.Is(err, ErrInvalidCommitment) {
+			t.Errorf("没有承诺时应该返回 ErrInvalidCommitment, 得到 %v", err)
+		}
+		if _, err := MergeCommitments([]*Commitment{commitA, nil}); !errors.Is(err, ErrInvalidCommitment) {
+			t.Errorf("承诺为 nil 时应该返回 ErrInvalidCommitment, 得到 %v", err)
+		}
+	})
+
+	t.Run("系数不在曲线上", func(t *testing.T) {
+		offCurve := ec.NewPoint(curve, big.NewInt(1), big.NewInt(2))
+		for pos := 0; pos < 2; pos++ {
+			bad := &Commitment{Curve: curve, Coeffs: append([]*ec.Point{}, commitB.Coeffs...)}
+			bad.Coeffs[1] = offCurve
+			commits := []*Commitment{commitA, bad}
+			if pos == 0 {
+				commits = []*Commitment{bad, commitA}
+			}
+			if _, err := MergeCommitments(commits); !errors.Is(err, ErrInvalidCommitment) {
+				t.Errorf("位置 %d: 应该返回 ErrInvalidCommitment, 得到 %v", pos, err)
+			}
+		}
+	})
+
+	t.Run("参数相同但实例不同的曲线", func(t *testing.T) {
+		params := *curve.Params()
+		rebuilt := &Commitment{Curve: &params, Coeffs: commitB.Coeffs}
+		if !commitB.Equal(rebuilt) || !rebuilt.Equal(commitB) {
+			t.Error("曲线参数相同的承诺应该相等")
+		}
+		got, err := MergeCommitments([]*Commitment{commitA, rebuilt})
+		if err != nil {
+			t.Fatalf("MergeCommitments 失败: %v", err)
+		}
+		if !got.Equal(merged) {
+			t.Error("合并结果应该与使用同一曲线实例时一致")
+		}
+	})
+}
+
+func TestCommitmentValidate(t *testing.T) {
+	curve := elliptic.P256()
+	indices, _ := SequentialIndices(curve, 3)
+	commit, _, err := SplitSecret(curve, 3, big.NewInt(42), indices)
+	if err != nil {
+		t.Fatalf("SplitSecret 失败: %v", err)
+	}
+
+	t.Run("正常承诺", func(t *testing.T) {
+		if commit.Degree() != 3 {
+			t.Errorf("Degree 应该是 3, 得到 %d", commit.Degree())
+		}
+		if err := commit.Validate(); err != nil {
+			t.Errorf("正常承诺应该通过 Validate: %v", err)
+		}
+		var nilCommit *Commitment
+		if nilCommit.Degree() != 0 {
+			t.Error("nil 承诺的 Degree 应该是 0")
+		}
+	})
+
+	t.Run("秘密为 0 时 C_0 是无穷远点", func(t *testing.T) {
+		zero, _, err := SplitSecret(curve, 2, big.NewInt(0), indices)
+		if err != nil {
+			t.Fatalf("SplitSecret 失败: %v", err)
+		}
+		if err := zero.Validate(); err != nil {
+			t.Errorf("含无穷远点的承诺应该通过 Validate: %v", err)
+		}
+	})
+
+	t.Run("系数的曲线实例不同但参数相同", func(t *testing.T) {
+		params := *curve.Params()
+		pt := commit.Coeffs[1]
+		rebuilt := &Commitment{Curve: curve, Coeffs: []*ec.Point{commit.Coeffs[0], {Curve: &params, X: pt.X, Y: pt.Y}}}
+		if err := rebuilt.Validate(); err != nil {
+			t.Errorf("参数相同的曲线应该通过 Validate: %v", err)
+		}
+	})
+
+	t.Run("系数不在曲线上", func(t *testing.T) {
+		bad := &Commitment{Curve: curve, Coeffs: append([]*ec.Point{}, commit.Coeffs...)}
+		bad.Coeffs[1] = ec.NewPoint(curve, big.NewInt(1), big.NewInt(2))
+		if err := bad.Validate(); !errors.Is(err, ErrInvalidCommitment) {
+			t.Errorf("应该返回 ErrInvalidCommitment, 得到 %v", err)
+		}
+	})
+
+	t.Run("结构错误", func(t *testing.T) {
+		cases := map[string]*Commitment{
+			"nil 承诺":  nil,
+			"无曲线":     {Coeffs: commit.Coeffs},
+			"无系数":     {Curve: curve},
+			"nil 系数":  {Curve: curve, Coeffs: []*ec.Point{commit.Coeffs[0], nil}},
+			"系数曲线不一致": {Curve: curve, Coeffs: []*ec.Point{ec.ScalarBaseMult(elliptic.P384(), big.NewInt(5))}},
+		}
+		for name, c := range cases {
+			if err := c.Validate(); !errors.Is(err, ErrInvalidCommitment) {
+				t.Errorf("%s: 应该返回 ErrInvalidCommitment, 得到 %v", name, err)
+			}
+		}
+	})
+}
+
+func TestVerifyCached(t *testing.T) {
+	curve := elliptic.P256()
+	indices := []Index{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4)}
+	commit, shares, err := SplitSecret(curve, 3, big.NewInt(777), indices)
+	if err != nil {
+		t.Fatalf("SplitSecret 失败: %v", err)
+	}
+
+	t.Run("与 Verify 一致", func(t *testing.T) {
+		wrong := &Share{Index: shares[0].Index, Value: big.NewInt(1), Threshold: 3}
+		cases := append(Shares{wrong, nil}, shares...)
+		for round := 0; round < 3; round++ {
+			for i, s := range cases {
+				if s.VerifyCached(curve, commit) != s.Verify(curve, commit) {
+					t.Errorf("第 %d 轮, share %d: VerifyCached 与 Verify 结果不同", round, i)
+				}
+			}
+		}
+		if len(commit.cache) != len(indices) {
+			t.Errorf("应该缓存 %d 个下标, 得到 %d", len(indices), len(commit.cache))
+		}
+	})
+
+	t.Run("下标按模 N 规范化", func(t *testing.T) {
+		shifted := &Share{
+			Index:     new(big.Int).Add(shares[1].Index, curve.Params().N),
+			Value:     shares[1].Value,
+			Threshold: shares[1].Threshold,
+		}
+		if !shifted.VerifyCached(curve, commit) {
+			t.Error("index + N 应该与 index 等价")
+		}
+	})
+
+	t.Run("ResetCache", func(t *testing.T) {
+		other, _, err := SplitSecret(curve, 3, big.NewInt(888), indices)
+		if err != nil {
+			t.Fatalf("SplitSecret 失败: %v", err)
+		}
+		c := &Commitment{Curve: curve, Coeffs: append([]*ec.Point{}, commit.Coeffs...)}
+		if !shares[0].VerifyCached(curve, c) {
+			t.Fatal("share 应该通过验证")
+		}
+		c.Coeffs = other.Coeffs
+		c.ResetCache()
+		if shares[0].VerifyCached(curve, c) {
+			t.Error("替换系数并清空缓存后不应该再通过验证")
+		}
+	})
+}
+
+func BenchmarkVerifyRepeated(b *testing.B) {
+	curve := elliptic.P256()
+	indices := make([]Index, 10)
+	for i := range indices {
+		indices[i] = big.NewInt(int64(i + 1))
+	}
+	commit, shares, err := SplitSecret(curve, 7, big.NewInt(4242), indices)
+	if err != nil {
+		b.Fatalf("SplitSecret 失败: %v", err)
+	}
+
+	b.Run("Verify", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if !shares[i%len(shares)].Verify(curve, commit) {
+				b.Fatal("验证失败")
+			}
+		}
+	})
+	b.Run("VerifyCached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if !shares[i%len(shares)].VerifyCached(curve, commit) {
+				b.Fatal("验证失败")
+			}
+		}
+	})
+}
+
+func TestCommitmentBuilder(t *testing.T) {
+	for _, curve := range []elliptic.Curve{elliptic.P256(), ec.Ed25519()} {
+		t.Run(curve.Params().Name, func(t *testing.T) {
+			N := curve.Params().N
+			poly := []*big.Int{
+				big.NewInt(777),
+				new(big.Int).Sub(N, big.NewInt(5)),
+				big.NewInt(-3), // 负系数按 mod N 解释
+				new(big.Int).Add(N, big.NewInt(9)),
+			}
+			indices, _ := SequentialIndices(curve, 5)
+			want, shares, err := SplitSecretWithPolynomial(curve, poly, indices)
+			if err != nil {
+				t.Fatalf("SplitSecretWithPolynomial 失败: %v", err)
+			}
+
+			b := NewCommitmentBuilder(curve)
+			for _, a := range poly {
+				b.AddCoefficient(a)
+			}
+			got := b.Build()
+			if got == nil {
+				t.Fatalf("Build 不应该返回 nil: %v", b.Err())
+			}
+			if !got.Equal(want) {
+				t.Error("增量构造的承诺应该与 SplitSecretWithPolynomial 的相同")
+			}
+			for i, s := range shares {
+				if !s.Verify(curve, got) {
+					t.Errorf("share[%d] 应该对增量构造的承诺验证通过", i)
+				}
+			}
+		})
+	}
+
+	t.Run("Build 之后继续追加不影响已返回的承诺", func(t *testing.T) {
+		curve := elliptic.P256()
+		b := NewCommitmentBuilder(curve)
+		b.AddCoefficient(big.NewInt(1))
+		b.AddCoefficient(big.NewInt(2))
+		first := b.Build()
+		b.AddCoefficient(big.NewInt(3))
+		if first.Degree() != 2 {
+			t.Errorf("已返回的承诺门限应该保持为 2, 得到 %d", first.Degree())
+		}
+		if b.Build().Degree() != 3 {
+			t.Error("再次 Build 应该包含新追加的系数")
+		}
+	})
+
+	t.Run("错误情形", func(t *testing.T) {
+		if NewCommitmentBuilder(elliptic.P256()).Build() != nil {
+			t.Error("没有系数时 Build 应该返回 nil")
+		}
+		if err := NewCommitmentBuilder(elliptic.P256()).Err(); !errors.Is(err, ErrThresholdTooSmall) {
+			t.Errorf("没有系数时应该返回 ErrThresholdTooSmall, 得到 %v", err)
+		}
+
+		b := NewCommitmentBuilder(nil)
+		b.AddCoefficient(big.NewInt(1))
+		if b.Build() != nil || !errors.Is(b.Err(), ErrNilCurve) {
+			t.Errorf("nil 曲线应该返回 ErrNilCurve, 得到 %v", b.Err())
+		}
+
+		b = NewCommitmentBuilder(elliptic.P256())
+		b.AddCoefficient(big.NewInt(1))
+		b.AddCoefficient(nil)
+		b.AddCoefficient(big.NewInt(2))
+		if b.Build() != nil || b.Err() == nil {
+			t.Error("nil 系数之后 Build 应该返回 nil")
+		}
+	})
+}
+
+func TestCommitmentHasDegree(t *testing.T) {
+	curve := elliptic.P256()
+	indices, _ := SequentialIndices(curve, 5)
+	commit, _, err := SplitSecret(curve, 3, big.NewInt(5), indices)
+	if err != nil {
+		t.Fatalf("SplitSecret 失败: %v", err)
+	}
+
+	t.Run("正确的次数", func(t *testing.T) {
+		if !commit.HasDegree(3) {
+			t.Error("门限为 3 的承诺应该通过 HasDegree(3)")
+		}
+	})
+
+	t.Run("错误的次数", func(t *testing.T) {
+		for _, threshold := range []int{-1, 0, 1, 2, 4, 5} {
+			if commit.HasDegree(threshold) {
+				t.Errorf("门限为 3 的承诺不应该通过 HasDegree(%d)", threshold)
+			}
+		}
+		// 恶意 dealer 多发一个系数
+		longer := &Commitment{Curve: curve, Coeffs: append(append([]*ec.Point{}, commit.Coeffs...), commit.Coeffs[0])}
+		if longer.HasDegree(3) {
+			t.Error("多一个系数的承诺不应该通过 HasDegree(3)")
+		}
+	})
+
+	t.Run("次数正确但点无效", func(t *testing.T) {
+		bad := &Commitment{Curve: curve, Coeffs: append([]*ec.Point{}, commit.Coeffs...)}
+		bad.Coeffs[1] = &ec.Point{Curve: curve, X: big.NewInt(1), Y: big.NewInt(1)}
+		if bad.HasDegree(3) {
+			t.Error("含曲线外点的承诺不应该通过 HasDegree")
+		}
+		bad.Coeffs[1] = nil
+		if bad.HasDegree(3) {
+			t.Error("含 nil 点的承诺不应该通过 HasDegree")
+		}
+	})
+
+	t.Run("nil 承诺", func(t *testing.T) {
+		var nilCommit *Commitment
+		if nilCommit.HasDegree(3) {
+			t.Error("nil 承诺不应该通过 HasDegree")
+		}
+	})
+}
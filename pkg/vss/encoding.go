@@ -0,0 +1,195 @@
+package vss
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"tss-crypto/pkg/ec"
+)
+
+// ---- 序列化 ----
+
+// commitmentASN1 是 Commitment 的 DER 编码结构：
+//
+//	Commitment ::= SEQUENCE {
+//	    curve   OBJECT IDENTIFIER,
+//	    coeffs  SEQUENCE OF SEQUENCE { x INTEGER, y INTEGER }
+//	}
+//
+// 曲线用标准 OID 标识，便于其他语言解析
+type commitmentASN1 struct {
+	Curve  asn1.ObjectIdentifier
+	Coeffs []pointASN1
+}
+
+type pointASN1 struct {
+	X *big.Int
+	Y *big.Int
+}
+
+// MarshalBinary 将承诺编码为 DER，曲线以 ASN.1 OID 标识
+func (c *Commitment) MarshalBinary() ([]byte, error) {
+	if c == nil || c.Curve == nil {
+		return nil, errors.New("commitment or curve is nil")
+	}
+	oid, ok := ec.CurveOID(c.Curve)
+	if !ok {
+		return nil, fmt.Errorf("unsupported curve %s", c.Curve.Params().Name)
+	}
+
+	enc := commitmentASN1{
+		Curve:  oid,
+		Coeffs: make([]pointASN1, len(c.Coeffs)),
+	}
+	for i, pt := range c.Coeffs {
+		if pt == nil || pt.X == nil || pt.Y == nil {
+			return nil, fmt.Errorf("commitment coefficient %d is nil", i)
+		}
+		enc.Coeffs[i] = pointASN1{X: pt.X, Y: pt.Y}
+	}
+	return asn1.Marshal(enc)
+}
+
+// UnmarshalBinary 从 DER 解码承诺，OID 映射回 elliptic.Curve，未知 OID 报错
+func (c *Commitment) UnmarshalBinary(data []byte) error {
+	var dec commitmentASN1
+	rest, err := asn1.Unmarshal(data, &dec)
+	if err != nil {
+		return fmt.Errorf("failed to decode commitment: %w", err)
+	}
+	if len(rest) != 0 {
+		return errors.New("trailing data after commitment")
+	}
+
+	curve, ok := ec.CurveFromOID(dec.Curve)
+	if !ok {
+		return fmt.Errorf("unknown curve OID %s", dec.Curve)
+	}
+	if len(dec.Coeffs) == 0 {
+		return errors.New("commitment has no coefficients")
+	}
+
+	coeffs := make([]*ec.Point, len(dec.Coeffs))
+	for i, p := range dec.Coeffs {
+		// 无穷远点按曲线各自的表示判断（NIST 为 (0,0)，Ed25519 为 (0,1)），其余点必须在曲线上
+		pt := ec.NewPoint(curve, p.X, p.Y)
+		if !pt.IsInfinity() && !pt.IsOnCurve() {
+			return fmt.Errorf("commitment coefficient %d is not on curve", i)
+		}
+		coeffs[i] = pt
+	}
+
+	c.Curve = curve
+	c.Coeffs = coeffs
+	c.ResetCache()
+	return nil
+}
+
+// ---- 审计打包 ----
+
+// bundleVersion 是 MarshalBundle 输出格式的版本号
+const bundleVersion byte = 1
+
+// 可用 errors.Is 判断的打包错误
+var (
+	ErrBundleVersion  = errors.New("unsupported bundle version")
+	ErrBundleChecksum = errors.New("bundle checksum mismatch")
+)
+
+// bundleASN1 是一次完整分享的 DER 结构，承诺部分直接嵌入 Commitment.MarshalBinary 的输出：
+//
+//	Bundle ::= SEQUENCE {
+//	    threshold   INTEGER,
+//	    commitment  Commitment,
+//	    shares      SEQUENCE OF SEQUENCE { index INTEGER, value INTEGER }
+//	}
+type bundleASN1 struct {
+	Threshold  int
+	Commitment asn1.RawValue
+	Shares     []shareASN1
+}
+
+type shareASN1 struct {
+	Index *big.Int
+	Value *big.Int
+}
+
+// MarshalBundle 把一次分享（曲线、门限、承诺和全部份额）打包成自描述的二进制，用于审计回放。
+// 格式为 version(1 字节) || DER(Bundle) || SHA-256(version || DER)
+func MarshalBundle(commit *Commitment, shares Shares) ([]byte, error) {
+	if err := commit.Validate(); err != nil {
+		return nil, err
+	}
+	commitDER, err := commit.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	enc := bundleASN1{
+		Threshold:  commit.Degree(),
+		Commitment: asn1.RawValue{FullBytes: commitDER},
+		Shares:     make([]shareASN1, len(shares)),
+	}
+	for i, s := range shares {
+		if s == nil || s.Index == nil || s.Value == nil {
+			return nil, fmt.Errorf("share %d is nil", i)
+		}
+		if s.Threshold != enc.Threshold {
+			return nil, fmt.Errorf("share %d has threshold %d, commitment has %d", i, s.Threshold, enc.Threshold)
+		}
+		enc.Shares[i] = shareASN1{Index: s.Index, Value: s.Value}
+	}
+	der, err := asn1.Marshal(enc)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, 1+len(der)+sha256.Size)
+	out = append(out, bundleVersion)
+	out = append(out, der...)
+	sum := sha256.Sum256(out)
+	return append(out, sum[:]...), nil
+}
+
+// UnmarshalBundle 解析 MarshalBundle 的输出：先检查版本号和校验和，再解码承诺与份额。
+// 不检查份额与承诺是否一致，调用方可对每份调用 Share.Verify
+func UnmarshalBundle(data []byte) (*Commitment, Shares, error) {
+	if len(data) < 1+sha256.Size {
+		return nil, nil, errors.New("bundle too short")
+	}
+	if data[0] != bundleVersion {
+		return nil, nil, fmt.Errorf("%w: %d", ErrBundleVersion, data[0])
+	}
+	body, checksum := data[:len(data)-sha256.Size], data[len(data)-sha256.Size:]
+	sum := sha256.Sum256(body)
+	if !bytes.Equal(sum[:], checksum) {
+		return nil, nil, ErrBundleChecksum
+	}
+
+	var dec bundleASN1
+	rest, err := asn1.Unmarshal(body[1:], &dec)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode bundle: %w", err)
+	}
+	if len(rest) != 0 {
+		return nil, nil, errors.New("trailing data after bundle")
+	}
+
+	commit := new(Commitment)
+	if err := commit.UnmarshalBinary(dec.Commitment.FullBytes); err != nil {
+		return nil, nil, err
+	}
+	if commit.Degree() != dec.Threshold {
+		return nil, nil, fmt.Errorf("bundle threshold %d does not match commitment degree %d", dec.Threshold, commit.Degree())
+	}
+
+	shares := make(Shares, len(dec.Shares))
+	for i, s := range dec.Shares {
+		shares[i] = &Share{Index: s.Index, Value: s.Value, Threshold: dec.Threshold}
+	}
+	return commit, shares, nil
+}
@@ -0,0 +1,206 @@
+package vss
+
+import (
+	"crypto/elliptic"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+	"testing"
+
+	"tss-crypto/pkg/ec"
+)
+
+func TestCommitment_MarshalBinary(t *testing.T) {
+	curves := []struct {
+		curve elliptic.Curve
+		oid   asn1.ObjectIdentifier
+	}{
+		{elliptic.P224(), asn1.ObjectIdentifier{1, 3, 132, 0, 33}},
+		{elliptic.P256(), asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}},
+		{elliptic.P384(), asn1.ObjectIdentifier{1, 3, 132, 0, 34}},
+		{elliptic.P521(), asn1.ObjectIdentifier{1, 3, 132, 0, 35}},
+	}
+	indices := []Index{big.NewInt(1), big.NewInt(2), big.NewInt(3)}
+
+	for _, tc := range curves {
+		t.Run(tc.curve.Params().Name, func(t *testing.T) {
+			commit, shares, err := SplitSecret(tc.curve, 3, big.NewInt(2024), indices)
+			if err != nil {
+				t.Fatalf("SplitSecret 失败: %v", err)
+			}
+
+			data, err := commit.MarshalBinary()
+			if err != nil {
+				t.Fatalf("MarshalBinary 失败: %v", err)
+			}
+
+			// 编码中的 OID 应该是标准 OID
+			var raw commitmentASN1
+			if _, err := asn1.Unmarshal(data, &raw); err != nil {
+				t.Fatalf("解析 DER 失败: %v", err)
+			}
+			if !raw.Curve.Equal(tc.oid) {
+				t.Errorf("OID 应该是 %v, 得到 %v", tc.oid, raw.Curve)
+			}
+
+			var decoded Commitment
+			if err := decoded.UnmarshalBinary(data); err != nil {
+				t.Fatalf("UnmarshalBinary 失败: %v", err)
+			}
+			if decoded.Curve != tc.curve {
+				t.Error("解码后的曲线不一致")
+			}
+			if len(decoded.Coeffs) != len(commit.Coeffs) {
+				t.Fatalf("系数个数应该是 %d, 得到 %d", len(commit.Coeffs), len(decoded.Coeffs))
+			}
+			for i := range commit.Coeffs {
+				if !decoded.Coeffs[i].Equal(commit.Coeffs[i]) {
+					t.Errorf("系数 %d 不一致", i)
+				}
+			}
+
+			// 解码后的承诺仍可用于验证
+			for i, share := range shares {
+				if !share.Verify(tc.curve, &decoded) {
+					t.Errorf("share[%d] 对解码后的承诺验证失败", i)
+				}
+			}
+		})
+	}
+
+	t.Run("未知 OID", func(t *testing.T) {
+		data, _ := asn1.Marshal(commitmentASN1{
+			Curve:  asn1.ObjectIdentifier{1, 2, 3, 4},
+			Coeffs: []pointASN1{{X: big.NewInt(1), Y: big.NewInt(2)}},
+		})
+		var decoded Commitment
+		if err := decoded.UnmarshalBinary(data); err == nil {
+			t.Error("应该返回错误当 OID 未知")
+		}
+	})
+
+	t.Run("点不在曲线上", func(t *testing.T) {
+		data, _ := asn1.Marshal(commitmentASN1{
+			Curve:  asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7},
+			Coeffs: []pointASN1{{X: big.NewInt(1), Y: big.NewInt(2)}},
+		})
+		var decoded Commitment
+		if err := decoded.UnmarshalBinary(data); err == nil {
+			t.Error("应该返回错误当点不在曲线上")
+		}
+	})
+
+	t.Run("Ed25519 往返与单位元", func(t *testing.T) {
+		curve := ec.Ed25519()
+		oid, ok := ec.CurveOID(curve)
+		if !ok {
+			t.Fatal("CurveOID 应该支持 Ed25519")
+		}
+		// secret = 0 时 C_0 是单位元 (0,1)
+		commit, shares, err := SplitSecret(curve, 3, big.NewInt(0), indices)
+		if err != nil {
+			t.Fatalf("SplitSecret 失败: %v", err)
+		}
+		data, err := commit.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary 失败: %v", err)
+		}
+		var decoded Commitment
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary 失败: %v", err)
+		}
+		if !decoded.Equal(commit) || !decoded.Coeffs[0].Equal(ec.Identity(curve)) {
+			t.Error("解码后的承诺应该与原承诺一致，且 C_0 为单位元")
+		}
+		for i, share := range shares {
+			if !share.Verify(curve, &decoded) {
+				t.Errorf("share[%d] 对解码后的承诺验证失败", i)
+			}
+		}
+
+		// (0,0) 不在 Ed25519 上，也不是它的单位元
+		bad, _ := asn1.Marshal(commitmentASN1{
+			Curve:  oid,
+			Coeffs: []pointASN1{{X: big.NewInt(0), Y: big.NewInt(0)}},
+		})
+		if err := decoded.UnmarshalBinary(bad); err == nil {
+			t.Error("Ed25519 上的 (0,0) 应该被拒绝")
+		}
+	})
+
+	t.Run("nil commitment", func(t *testing.T) {
+		var commit *Commitment
+		if _, err := commit.MarshalBinary(); err == nil {
+			t.Error("应该返回错误当 commitment 为 nil")
+		}
+	})
+}
+
+func TestBundle(t *testing.T) {
+	curve := elliptic.P256()
+	indices, _ := SequentialIndices(curve, 5)
+	commit, shares, err := SplitSecret(curve, 3, big.NewInt(31337), indices)
+	if err != nil {
+		t.Fatalf("SplitSecret 失败: %v", err)
+	}
+	data, err := MarshalBundle(commit, shares)
+	if err != nil {
+		t.Fatalf("MarshalBundle 失败: %v", err)
+	}
+
+	t.Run("往返", func(t *testing.T) {
+		decCommit, decShares, err := UnmarshalBundle(data)
+		if err != nil {
+			t.Fatalf("UnmarshalBundle 失败: %v", err)
+		}
+		if !decCommit.Equal(commit) {
+			t.Error("解码后的承诺不一致")
+		}
+		if len(decShares) != len(shares) {
+			t.Fatalf("份额个数应该是 %d, 得到 %d", len(shares), len(decShares))
+		}
+		for i, s := range decShares {
+			if s.Index.Cmp(shares[i].Index) != 0 || s.Value.Cmp(shares[i].Value) != 0 || s.Threshold != 3 {
+				t.Errorf("share[%d] 不一致", i)
+			}
+			if !s.Verify(curve, decCommit) {
+				t.Errorf("share[%d] 对解码后的承诺验证失败", i)
+			}
+		}
+		secret, err := Reconstruct(curve, 3, decShares[1:4])
+		if err != nil || secret.Cmp(big.NewInt(31337)) != 0 {
+			t.Errorf("用解码后的份额重建秘密失败: %v, %v", secret, err)
+		}
+	})
+
+	t.Run("任一字节损坏都被拒绝", func(t *testing.T) {
+		for i := 1; i < len(data); i++ {
+			corrupted := append([]byte{}, data...)
+			corrupted[i] ^= 0x01
+			if _, _, err := UnmarshalBundle(corrupted); !errors.Is(err, ErrBundleChecksum) {
+				t.Fatalf("第 %d 字节损坏时应该返回 ErrBundleChecksum, 得到 %v", i, err)
+			}
+		}
+	})
+
+	t.Run("版本不匹配", func(t *testing.T) {
+		corrupted := append([]byte{}, data...)
+		corrupted[0] = bundleVersion + 1
+		if _, _, err := UnmarshalBundle(corrupted); !errors.Is(err, ErrBundleVersion) {
+			t.Errorf("应该返回 ErrBundleVersion, 得到 %v", err)
+		}
+	})
+
+	t.Run("截断", func(t *testing.T) {
+		if _, _, err := UnmarshalBundle(data[:10]); err == nil {
+			t.Error("应该返回错误当数据被截断")
+		}
+	})
+
+	t.Run("份额门限与承诺不一致", func(t *testing.T) {
+		bad := Shares{&Share{Index: big.NewInt(1), Value: big.NewInt(1), Threshold: 2}}
+		if _, err := MarshalBundle(commit, bad); err == nil {
+			t.Error("应该返回错误当份额门限与承诺次数不一致")
+		}
+	})
+}
@@ -2,15 +2,32 @@ package vss
 
 import (
 	"crypto/elliptic"
+	"crypto/hkdf"
 	"crypto/rand"
+	"crypto/sha256"
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
+	"sync"
 
 	"tss-crypto/pkg/ec"
 	"tss-crypto/pkg/mod"
 )
 
+// ---- 错误 ----
+
+// 可用 errors.Is 判断的错误类型，具体错误会用 %w 包装并附带上下文
+var (
+	ErrNilCurve          = errors.New("curve is nil")
+	ErrThresholdTooSmall = errors.New("threshold must be at least 1")
+	ErrNotEnoughShares   = errors.New("not enough shares")
+	ErrDuplicateIndex    = errors.New("indices contain duplicates after normalization")
+	ErrZeroIndex         = errors.New("index after mod N cannot be zero")
+	ErrIndexNotUnit      = errors.New("index is not invertible modulo N")
+	ErrInvalidCommitment = errors.New("invalid commitment")
+)
+
 // Index 是参与方的 x 坐标，通常是 1,2,3... 这样的非零值
 type Index = *big.Int
 
@@ -29,6 +46,10 @@ type Shares []*Share
 type Commitment struct {
 	Curve  elliptic.Curve
 	Coeffs []*ec.Point // C_0..C_{t-1}
+
+	// VerifyCached 使用的缓存：规范化下标 -> Σ C_j·index^j
+	cacheMu sync.Mutex
+	cache   map[string]*ec.Point
 }
 
 // ---- 公开 API ----
@@ -36,81 +57,345 @@ type Commitment struct {
 // Split 对 secret 做 Shamir+Feldman VSS 拆分，返回多项式承诺和所有份额
 // indices 长度 = 要发出去的 share 个数；如果为空你也可以选择内部自动生成 1..n
 func SplitSecret(curve elliptic.Curve, threshold int, secret *big.Int, indices []Index) (*Commitment, Shares, error) {
+	return SplitSecretWithReader(rand.Reader, curve, threshold, secret, indices)
+}
+
+// SplitSecretWithReader 与 SplitSecret 相同，但多项式的随机系数从 random 读取，
+// 便于接入 FIPS DRBG 或 HSM 等合规随机源；random 为 nil 时使用 crypto/rand
+func SplitSecretWithReader(random io.Reader, curve elliptic.Curve, threshold int, secret *big.Int, indices []Index) (*Commitment, Shares, error) {
 	// 输入检查合并
-	if curve == nil || secret == nil {
-		return nil, nil, fmt.Errorf("curve or secret is nil")
+	if curve == nil {
+		return nil, nil, ErrNilCurve
+	}
+	if secret == nil {
+		return nil, nil, fmt.Errorf("secret is nil")
+	}
+	if err := ValidateThreshold(threshold, len(indices)); err != nil {
+		return nil, nil, err
+	}
+
+	// 生成多项式
+	polynomial, err := generateRandomPolynomial(random, threshold, secret, curve.Params().N)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return SplitSecretWithPolynomial(curve, polynomial, indices)
+}
+
+// SplitSecretDeterministic 与 SplitSecret 相同，但高次系数 a_1..a_{t-1} 由 seed 经 HKDF-SHA256
+// 确定性派生（a_0 = secret）。相同的 seed 与输入总是得到相同的承诺和份额，
+// 便于 commit-reveal 式 DKG 复现 dealer 的输出。seed 须保密且具有足够熵（如随机种子与 transcript 哈希的组合）
+func SplitSecretDeterministic(curve elliptic.Curve, threshold int, secret *big.Int, indices []Index, seed []byte) (*Commitment, Shares, error) {
+	if curve == nil {
+		return nil, nil, ErrNilCurve
+	}
+	if secret == nil {
+		return nil, nil, fmt.Errorf("secret is nil")
+	}
+	if len(seed) == 0 {
+		return nil, nil, fmt.Errorf("seed is empty")
+	}
+	if err := ValidateThreshold(threshold, len(indices)); err != nil {
+		return nil, nil, err
+	}
+
+	polynomial, err := derivePolynomial(curve, threshold, secret, seed)
+	if err != nil {
+		return nil, nil, err
+	}
+	return SplitSecretWithPolynomial(curve, polynomial, indices)
+}
+
+// RegenerateShare 由 secret 与 SplitSecretDeterministic 用过的 seed 重新派生同一个多项式，
+// 只在 index 处求值，得到与原先完全相同的份额。用于备份恢复：丢失的份额可以单独补发，
+// 且能对原承诺验证通过。threshold、secret、seed 必须与拆分时一致，否则得到的是另一个多项式的份额
+func RegenerateShare(curve elliptic.Curve, threshold int, secret *big.Int, seed []byte, index Index) (*Share, error) {
+	if curve == nil {
+		return nil, ErrNilCurve
+	}
+	if secret == nil {
+		return nil, fmt.Errorf("secret is nil")
+	}
+	if len(seed) == 0 {
+		return nil, fmt.Errorf("seed is empty")
 	}
 	if threshold < 1 {
-		return nil, nil, fmt.Errorf("threshold must be at least 1")
+		return nil, ErrThresholdTooSmall
+	}
+	if index == nil {
+		return nil, fmt.Errorf("index is nil")
+	}
+	// 与拆分路径一致：下标取 mod N，拒绝 0
+	normalized, err := CheckIndices(curve, []Index{index})
+	if err != nil {
+		return nil, err
+	}
+
+	polynomial, err := derivePolynomial(curve, threshold, secret, seed)
+	if err != nil {
+		return nil, err
+	}
+	return EvaluateShare(curve, polynomial, normalized[0])
+}
+
+// SplitSecretWithPolynomial 使用调用方给定的多项式系数 a_0..a_{t-1} 做拆分
+// 其中 a_0 = secret，threshold = len(polynomial)；系数按 mod N 处理
+// 适用于需要保留多项式（例如之后用 EvaluateShare 为新参与方补发份额）的场景
+func SplitSecretWithPolynomial(curve elliptic.Curve, polynomial []*big.Int, indices []Index) (*Commitment, Shares, error) {
+	if curve == nil {
+		return nil, nil, ErrNilCurve
+	}
+	threshold := len(polynomial)
+	if threshold < 1 {
+		return nil, nil, ErrThresholdTooSmall
 	}
 	if len(indices) == 0 {
 		return nil, nil, fmt.Errorf("indices is nil or empty")
 	}
-	if len(indices) < threshold {
-		return nil, nil, fmt.Errorf("indices length is less than threshold")
+	if err := ValidateThreshold(threshold, len(indices)); err != nil {
+		return nil, nil, err
+	}
+	// 与重建路径统一：索引取 mod N，拒绝 0 和重复
+	indices, err := CheckIndices(curve, indices)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// 生成多项式
-	polynomial := generateRandomPolynomial(curve, threshold, secret)
+	N := curve.Params().N
+	coeffs := make([]*big.Int, threshold)
+	for i, a := range polynomial {
+		if a == nil {
+			return nil, nil, fmt.Errorf("polynomial coefficient %d is nil", i)
+		}
+		coeffs[i] = mod.Mod(a, N)
+	}
 
 	// 计算承诺，复用 commitment := &Commitment{ ... }
 	commitment := &Commitment{
 		Curve:  curve,
 		Coeffs: make([]*ec.Point, threshold),
 	}
-	for i, coeff := range polynomial {
-		commitment.Coeffs[i] = ec.ScalarBaseMult(curve, coeff)
+	table := ec.NewBaseMultTable(curve)
+	for i, coeff := range coeffs {
+		commitment.Coeffs[i] = table.Mult(coeff)
 	}
 
 	shares := make(Shares, len(indices))
 	for i, index := range indices {
-		shares[i] = &Share{
-			Index:     index,
-			Value:     computeShare(curve, polynomial, index, threshold),
-			Threshold: threshold,
+		share, err := EvaluateShare(curve, coeffs, index)
+		if err != nil {
+			return nil, nil, err
 		}
+		shares[i] = share
 	}
 
 	return commitment, shares, nil
 }
 
+// EvaluateShare 在 index 处求多项式的值，生成单个参与方的份额
+// 与 SplitSecretWithPolynomial 配合，可在不重新分享的情况下为新参与方补发份额。
+// index 与拆分路径一样取 mod N 并拒绝 0；poly 不能为空或含 nil 系数
+func EvaluateShare(curve elliptic.Curve, poly []*big.Int, index Index) (*Share, error) {
+	if curve == nil {
+		return nil, ErrNilCurve
+	}
+	if len(poly) == 0 {
+		return nil, ErrThresholdTooSmall
+	}
+	for i, a := range poly {
+		if a == nil {
+			return nil, fmt.Errorf("polynomial coefficient %d is nil", i)
+		}
+	}
+	if index == nil {
+		return nil, fmt.Errorf("index is nil")
+	}
+	normalized, err := CheckIndices(curve, []Index{index})
+	if err != nil {
+		return nil, err
+	}
+	return &Share{
+		Index:     normalized[0],
+		Value:     computeShare(poly, normalized[0], curve.Params().N),
+		Threshold: len(poly),
+	}, nil
+}
+
 // Reconstruct 使用至少 t 个 share 恢复 secret
 func Reconstruct(curve elliptic.Curve, threshold int, shares Shares) (*big.Int, error) {
+	return ReconstructAt(curve, threshold, shares, big.NewInt(0))
+}
+
+// ReconstructAt 使用至少 t 个 share 插值出多项式在任意 x 处的值 f(x)
+// x = 0 即 Reconstruct；x 取某个 share 的 index 时返回该 share 的值
+func ReconstructAt(curve elliptic.Curve, threshold int, shares Shares, x *big.Int) (*big.Int, error) {
 	if curve == nil {
-		return nil, fmt.Errorf("curve is nil")
+		return nil, ErrNilCurve
+	}
+	if threshold < 1 {
+		return nil, ErrThresholdTooSmall
 	}
 	if len(shares) < threshold {
-		return nil, fmt.Errorf("need at least %d shares to reconstruct, got %d", threshold, len(shares))
+		return nil, fmt.Errorf("need at least %d shares to reconstruct, got %d: %w", threshold, len(shares), ErrNotEnoughShares)
+	}
+	return interpolateAt(shares, threshold, x, curve.Params().N)
+}
+
+// ReconstructForce 忽略每个 share 的 Threshold 字段，用前 threshold 个非 nil 的 share
+// 按 threshold-1 次多项式插值出 secret。用于 resharing 等场景：share 上记录的门限已过时，
+// 但调用方确知正确的插值次数。
+//
+// 风险：Reconstruct 借 Threshold 字段拒绝混入的其他多项式的 share，这里不再有这层保护。
+// threshold 给错或混入了不属于同一多项式的 share 时，会静默得到错误的 secret 而不报错，
+// 调用方应事先用承诺验证 share，或事后用 secret·G 与已知公钥比对
+func ReconstructForce(curve elliptic.Curve, threshold int, shares Shares) (*big.Int, error) {
+	if curve == nil {
+		return nil, ErrNilCurve
+	}
+	if threshold < 1 {
+		return nil, ErrThresholdTooSmall
 	}
-	N := curve.Params().N
-	// 选取前 threshold 个非 nil 且 threshold 匹配的 share
 	selected := make([]*Share, 0, threshold)
 	for _, s := range shares {
-		if s != nil && s.Threshold == threshold {
-			selected = append(selected, s)
-			if len(selected) == threshold {
-				break
-			}
+		if s == nil || s.Index == nil || s.Value == nil {
+			continue
+		}
+		selected = append(selected, s)
+		if len(selected) == threshold {
+			break
 		}
 	}
 	if len(selected) < threshold {
-		return nil, fmt.Errorf("valid shares fewer than threshold")
+		return nil, fmt.Errorf("need %d usable shares to reconstruct, got %d: %w", threshold, len(selected), ErrNotEnoughShares)
 	}
+	return interpolateSelected(selected, big.NewInt(0), curve.Params().N)
+}
 
-	// 计算所有拉格朗日插值系数
-	lambdaCoeffs, err := lagrangeCoefficients(selected, N)
+// ReconstructWithPublic 恢复 secret 并一并返回 secret·G，便于直接发布为群公钥。
+// 份额一致时返回的点等于承诺的 C_0
+func ReconstructWithPublic(curve elliptic.Curve, threshold int, shares Shares) (*big.Int, *ec.Point, error) {
+	secret, err := Reconstruct(curve, threshold, shares)
+	if err != nil {
+		return nil, nil, err
+	}
+	return secret, ec.ScalarBaseMult(curve, secret), nil
+}
+
+// Finalize 是 DKG 收尾的便捷函数：用 commit 验证 shares 中的每一份，只用通过验证的份额
+// 恢复 secret，并返回 secret·G。通过验证的份额不足 threshold 份时返回包装 ErrNotEnoughShares 的错误
+func Finalize(curve elliptic.Curve, threshold int, shares Shares, commit *Commitment) (*big.Int, *ec.Point, error) {
+	if curve == nil {
+		return nil, nil, ErrNilCurve
+	}
+	if err := commit.Validate(); err != nil {
+		return nil, nil, err
+	}
+	valid := make(Shares, 0, len(shares))
+	for _, s := range shares {
+		if s.Verify(curve, commit) {
+			valid = append(valid, s)
+		}
+	}
+	if len(valid) < threshold {
+		return nil, nil, fmt.Errorf("only %d of %d shares verify, need %d: %w",
+			len(valid), len(shares), threshold, ErrNotEnoughShares)
+	}
+	return ReconstructWithPublic(curve, threshold, valid)
+}
+
+// InterpolatePoints 在指数上做 Lagrange 插值：给定公开份额 Y_i = s_i·G 及其下标，
+// 计算 Σ λ_i(0)·Y_i = secret·G，无需知道各 s_i。
+// 所有给出的点都参与插值，调用方应传入同一多项式上的至少 t 个点
+func InterpolatePoints(curve elliptic.Curve, indices []Index, points []*ec.Point) (*ec.Point, error) {
+	if curve == nil {
+		return nil, ErrNilCurve
+	}
+	if len(indices) != len(points) {
+		return nil, fmt.Errorf("got %d indices but %d points", len(indices), len(points))
+	}
+	if len(points) == 0 {
+		return nil, fmt.Errorf("no points to interpolate: %w", ErrNotEnoughShares)
+	}
+	// 统一挂到 curve 上，使参数相同但实例不同的点也能相加
+	ys := make([]*ec.Point, len(points))
+	for i, pt := range points {
+		if pt == nil || !ec.SameCurve(pt.Curve, curve) {
+			return nil, fmt.Errorf("point %d is nil or on a different curve", i)
+		}
+		if !pt.IsOnCurve() {
+			return nil, fmt.Errorf("point %d is not on curve", i)
+		}
+		ys[i] = &ec.Point{Curve: curve, X: pt.X, Y: pt.Y}
+	}
+
+	lambdas, err := LagrangeCoefficients(curve, indices, big.NewInt(0))
 	if err != nil {
 		return nil, err
 	}
 
-	secret := big.NewInt(0)
-	for i := 0; i < threshold; i++ {
-		si := selected[i]
-		lagCoeff := lambdaCoeffs[i]
-		part := mod.ModMul(si.Value, lagCoeff, N)
-		secret = mod.ModAdd(secret, part, N)
+	result := ys[0].ScalarMult(lambdas[0])
+	for i, pt := range ys[1:] {
+		result = result.Add(pt.ScalarMult(lambdas[i+1]))
 	}
-	return secret, nil
+	return result, nil
+}
+
+// LagrangeCoefficients 返回下标集合 indices 在 x 处的 Lagrange 插值系数 λ_i(x)（mod N），
+// 顺序与 indices 一致。下标经 CheckIndices 规范化，0 或重复时返回错误
+func LagrangeCoefficients(curve elliptic.Curve, indices []Index, x *big.Int) ([]*big.Int, error) {
+	if curve == nil {
+		return nil, ErrNilCurve
+	}
+	N := curve.Params().N
+	indices, err := checkIndices(indices, N)
+	if err != nil {
+		return nil, err
+	}
+	// lagrangeCoefficients 只用到下标
+	selected := make([]*Share, len(indices))
+	for i, index := range indices {
+		selected[i] = &Share{Index: index}
+	}
+	return lagrangeCoefficients(selected, x, N)
+}
+
+// ToAdditiveShares 把签名子集持有的 Shamir 份额转换为加法份额：w_i = λ_i(0)·f(x_i) mod N，
+// 其中 λ_i 是在整个子集上计算的 Lagrange 系数，因此 Σ w_i = secret (mod N)。
+// shares 中的每份都参与转换（不足 threshold 份时报错），返回的 map 以规范化下标的十进制字符串为键
+func ToAdditiveShares(curve elliptic.Curve, threshold int, shares Shares) (map[string]*big.Int, error) {
+	if curve == nil {
+		return nil, ErrNilCurve
+	}
+	if err := ValidateThreshold(threshold, len(shares)); err != nil {
+		return nil, err
+	}
+
+	N := curve.Params().N
+	indices := make([]Index, len(shares))
+	for i, s := range shares {
+		if s == nil || s.Index == nil || s.Value == nil {
+			return nil, fmt.Errorf("share %d is nil", i)
+		}
+		if s.Threshold != threshold {
+			return nil, fmt.Errorf("share %d has threshold %d, expected %d", i, s.Threshold, threshold)
+		}
+		indices[i] = s.Index
+	}
+	indices, err := checkIndices(indices, N)
+	if err != nil {
+		return nil, err
+	}
+
+	lambdas, err := lagrangeCoefficients(shares, big.NewInt(0), N)
+	if err != nil {
+		return nil, err
+	}
+	additive := make(map[string]*big.Int, len(shares))
+	for i, s := range shares {
+		additive[indices[i].String()] = mod.ModMul(s.Value, lambdas[i], N)
+	}
+	return additive, nil
 }
 
 // Verify 验证 Feldman VSS 下某个 share 是否有效
@@ -123,31 +408,17 @@ func (s *Share) Verify(curve elliptic.Curve, commit *Commitment) bool {
 	// 基本输入检查
 	if s == nil || commit == nil ||
 		s.Index == nil || s.Value == nil ||
-		s.Threshold < 1 || s.Threshold != len(commit.Coeffs) {
+		s.Threshold < 1 || s.Threshold != commit.Degree() {
 		return false
 	}
 
-	// 检查曲线一致性
-	if curve != commit.Curve {
+	// 检查曲线一致性：按参数比较，分别构造的同一条曲线也视为一致
+	if !ec.SameCurve(curve, commit.Curve) {
 		return false
 	}
 
-	N := curve.Params().N
-
-	// 累加承诺多项式的点值：result = C_0
-	result := commit.Coeffs[0].Copy()
-
-	// exp = index，后续exp依次乘index得到 index^2, index^3, ...
-	exp := new(big.Int).Set(s.Index)
-	for _, c := range commit.Coeffs[1:] {
-		// 计算 C_i * index^i
-		// 公式：EC_point = c * exp
-		pt := c.ScalarMult(exp)
-		// 累加到总和上
-		result = result.Add(pt)
-		// exp = exp * index mod N，得到下一个index的幂
-		exp = mod.ModMul(exp, s.Index, N)
-	}
+	// 计算右侧：Σ C_i * index^i
+	result := commit.evaluate(s.Index)
 
 	// 计算左侧期望结果: 基点G * share_value
 	expected := ec.ScalarBaseMult(curve, s.Value)
@@ -156,23 +427,98 @@ func (s *Share) Verify(curve elliptic.Curve, commit *Commitment) bool {
 	return result.Equal(expected)
 }
 
+// VerifyWithPublicShare 用已知的公开份额 Y_i = s_i·G 验证承诺：
+// 只计算 Σ C_j · index^j 并与 publicShare 比较，省去 Verify 中的 ScalarBaseMult。
+// 适用于已经持有各方公开份额（例如 DKG 后广播的 X_i）、需要批量核对的场景
+func VerifyWithPublicShare(curve elliptic.Curve, commit *Commitment, index Index, publicShare *ec.Point) bool {
+	if commit == nil || len(commit.Coeffs) == 0 || index == nil || publicShare == nil {
+		return false
+	}
+	if !ec.SameCurve(curve, commit.Curve) || !ec.SameCurve(curve, publicShare.Curve) {
+		return false
+	}
+	return commit.evaluate(index).Equal(publicShare)
+}
+
 // CheckIndices 规范化/检查索引：取 mod N，不能为 0，不能重复
 func CheckIndices(curve elliptic.Curve, indices []Index) ([]Index, error) {
+	if curve == nil {
+		return nil, ErrNilCurve
+	}
+	return checkIndices(indices, curve.Params().N)
+}
+
+// ValidateThreshold 检查门限参数满足 1 <= threshold <= n（n 为参与方个数）。
+// threshold < 1 返回包装 ErrThresholdTooSmall 的错误，threshold > n 返回包装 ErrNotEnoughShares 的错误
+func ValidateThreshold(threshold, n int) error {
+	if threshold < 1 {
+		return fmt.Errorf("threshold %d: %w", threshold, ErrThresholdTooSmall)
+	}
+	if threshold > n {
+		return fmt.Errorf("threshold %d exceeds number of participants %d: %w", threshold, n, ErrNotEnoughShares)
+	}
+	return nil
+}
+
+// CheckIndicesMapped 与 CheckIndices 相同，另外返回 规范化下标（十进制字符串）-> 原始下标 的映射，
+// 便于调用方把取模后的下标对应回原来的参与方标识
+func CheckIndicesMapped(curve elliptic.Curve, indices []Index) ([]Index, map[string]Index, error) {
+	normalized, err := CheckIndices(curve, indices)
+	if err != nil {
+		return nil, nil, err
+	}
+	originals := make(map[string]Index, len(indices))
+	for i, norm := range normalized {
+		originals[norm.String()] = indices[i]
+	}
+	return normalized, originals, nil
+}
+
+// SequentialIndices 返回参与方下标 1..n，并经 CheckIndices 校验
+func SequentialIndices(curve elliptic.Curve, n int) ([]Index, error) {
+	if n < 1 {
+		return nil, errors.New("number of indices must be at least 1")
+	}
+	indices := make([]Index, n)
+	for i := range indices {
+		indices[i] = big.NewInt(int64(i + 1))
+	}
+	return CheckIndices(curve, indices)
+}
+
+// IndicesFromIDs 将参与方 ID 映射为下标，并经 CheckIndices 校验。
+// ID 为 0 会得到被禁止的下标 0，返回 ErrZeroIndex
+func IndicesFromIDs(curve elliptic.Curve, ids []uint32) ([]Index, error) {
+	indices := make([]Index, len(ids))
+	for i, id := range ids {
+		indices[i] = new(big.Int).SetUint64(uint64(id))
+	}
+	return CheckIndices(curve, indices)
+}
+
+// ---- 内部实现 ----
+
+// checkIndices 在模数 N 下规范化/检查索引：取 mod N，不能为 0，不能重复。
+// N 为合数时（SplitSecretShamir 允许任意模数）还要求索引与 N 互素，否则返回 ErrIndexNotUnit；
+// 曲线阶 N 为素数，这一检查不会额外拒绝任何非零索引
+func checkIndices(indices []Index, N *big.Int) ([]Index, error) {
 	if len(indices) == 0 {
 		return nil, errors.New("indices list is empty")
 	}
-	N := curve.Params().N
 	normalized := make([]Index, len(indices))
 	uniq := make(map[string]bool)
 
 	for i, idx := range indices {
 		norm := mod.Mod(idx, N)
 		if norm.Sign() == 0 {
-			return nil, errors.New("index after mod N cannot be zero")
+			return nil, fmt.Errorf("index %d: %w", i, ErrZeroIndex)
+		}
+		if !mod.IsUnit(norm, N) {
+			return nil, fmt.Errorf("index %d: %w", i, ErrIndexNotUnit)
 		}
 		key := norm.String()
 		if uniq[key] {
-			return nil, errors.New("indices contain duplicates after normalization")
+			return nil, fmt.Errorf("index %d: %w", i, ErrDuplicateIndex)
 		}
 		uniq[key] = true
 		normalized[i] = norm
@@ -180,38 +526,106 @@ func CheckIndices(curve elliptic.Curve, indices []Index) ([]Index, error) {
 	return normalized, nil
 }
 
-// ---- 内部实现 ----
-
-// 生成随机多项式系数
-func generateRandomPolynomial(curve elliptic.Curve, threshold int, secret *big.Int) []*big.Int {
+// 生成模 N 下的随机多项式系数，random 为 nil 时使用 crypto/rand
+func generateRandomPolynomial(random io.Reader, threshold int, secret *big.Int, N *big.Int) ([]*big.Int, error) {
+	if random == nil {
+		random = rand.Reader
+	}
 	coefficients := make([]*big.Int, threshold)
 	coefficients[0] = secret
 	for i := 1; i < threshold; i++ {
-		r, err := rand.Int(rand.Reader, curve.Params().N)
+		r, err := rand.Int(random, N)
 		if err != nil {
-			panic(err) // Panic as a placeholder, consider handling error properly
+			return nil, fmt.Errorf("sample coefficient %d: %w", i, err)
 		}
 		coefficients[i] = r
 	}
-	return coefficients
+	return coefficients, nil
 }
 
-// 计算多项式 f(index) = a0 + a1*index + a2*index^2 + ... + at*index^t (mod N)
-func computeShare(curve elliptic.Curve, coefficients []*big.Int, index Index, threshold int) *big.Int {
+// derivePolynomial 用 HKDF-SHA256 从 seed 派生系数 a_1..a_{t-1}，a_0 = secret mod N。
+// 输入密钥材料为 seed || secret，info 绑定曲线名、门限与系数序号；
+// 每个系数多取 16 字节再取模，使取模偏差可忽略
+func derivePolynomial(curve elliptic.Curve, threshold int, secret *big.Int, seed []byte) ([]*big.Int, error) {
 	N := curve.Params().N
+	byteLen := (N.BitLen() + 7) / 8
+	secretMod := mod.Mod(secret, N)
+
+	// secretMod < N，总能放进 byteLen 字节
+	secretBytes, _ := mod.ToFixedBytes(secretMod, byteLen)
+	ikm := append(append([]byte{}, seed...), secretBytes...)
+
+	coefficients := make([]*big.Int, threshold)
+	coefficients[0] = secretMod
+	for i := 1; i < threshold; i++ {
+		info := fmt.Sprintf("tss-crypto/vss polynomial %s t=%d a_%d", curve.Params().Name, threshold, i)
+		out, err := hkdf.Key(sha256.New, ikm, nil, info, byteLen+16)
+		if err != nil {
+			return nil, err
+		}
+		coefficients[i] = mod.Mod(new(big.Int).SetBytes(out), N)
+	}
+	return coefficients, nil
+}
+
+// 计算多项式 f(index) = a0 + a1*index + a2*index^2 + ... + at*index^t (mod N)
+func computeShare(coefficients []*big.Int, index Index, N *big.Int) *big.Int {
 	share := big.NewInt(0)
 
-	for i := 0; i < threshold; i++ {
-		// term = a_i * index^i (mod N)
-		exp := mod.ModExp(index, big.NewInt(int64(i)), N) // index^i mod N
-		term := mod.ModMul(coefficients[i], exp, N)       // a_i * index^i mod N
+	for i, a := range coefficients {
+		// term = a_i * index^i (mod N)；指数非负，ModExp 不会返回错误
+		exp, _ := mod.ModExp(index, big.NewInt(int64(i)), N) // index^i mod N
+		term := mod.ModMul(a, exp, N)                        // a_i * index^i mod N
 		share = mod.ModAdd(share, term, N)
 	}
 	return share
 }
 
-// lagrangeCoefficients 计算拉格朗日插值系数 λ0, λ1, ..., λ_{n-1}
-func lagrangeCoefficients(shares []*Share, N *big.Int) ([]*big.Int, error) {
+// interpolateAt 在模 N 下用前 threshold 个有效 share 插值出 f(x)
+func interpolateAt(shares Shares, threshold int, x *big.Int, N *big.Int) (*big.Int, error) {
+	// 选取前 threshold 个非 nil 且 threshold 匹配的 share
+	selected := make([]*Share, 0, threshold)
+	nonNil := 0
+	for _, s := range shares {
+		if s == nil {
+			continue
+		}
+		nonNil++
+		if s.Threshold == threshold && len(selected) < threshold {
+			selected = append(selected, s)
+		}
+	}
+	// 区分两种失败：nil 太多（调用方常以为凑够了 threshold 个），与 threshold 字段不匹配
+	if nonNil < threshold {
+		return nil, fmt.Errorf("not enough non-nil shares: got %d non-nil of %d, need %d: %w",
+			nonNil, len(shares), threshold, ErrNotEnoughShares)
+	}
+	if len(selected) < threshold {
+		return nil, fmt.Errorf("only %d shares have threshold %d, need %d: %w",
+			len(selected), threshold, threshold, ErrNotEnoughShares)
+	}
+
+	return interpolateSelected(selected, x, N)
+}
+
+// interpolateSelected 用 selected 中的全部 share 插值出 f(x)，不再检查 Threshold 字段
+func interpolateSelected(selected []*Share, x *big.Int, N *big.Int) (*big.Int, error) {
+	// 计算所有拉格朗日插值系数
+	lambdaCoeffs, err := lagrangeCoefficients(selected, x, N)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := make([]*big.Int, len(selected))
+	for i, si := range selected {
+		parts[i] = mod.ModMul(si.Value, lambdaCoeffs[i], N)
+	}
+	return mod.ModSum(N, parts...), nil
+}
+
+// lagrangeCoefficients 计算在 x 处的拉格朗日插值系数 λ0, λ1, ..., λ_{n-1}
+// λ_i(x) = Π_{j≠i} (x_j - x) / (x_j - x_i)  (mod N)
+func lagrangeCoefficients(shares []*Share, x *big.Int, N *big.Int) ([]*big.Int, error) {
 	n := len(shares)
 	lambdas := make([]*big.Int, n)
 	for i := 0; i < n; i++ {
@@ -223,7 +637,7 @@ func lagrangeCoefficients(shares []*Share, N *big.Int) ([]*big.Int, error) {
 				continue
 			}
 			sj := shares[j]
-			num = mod.ModMul(num, sj.Index, N)
+			num = mod.ModMul(num, mod.ModSub(sj.Index, x, N), N)
 			tmp := mod.ModSub(sj.Index, si.Index, N) // Ensure positive modulo
 			den = mod.ModMul(den, tmp, N)
 		}
@@ -1,9 +1,19 @@
 package vss
 
 import (
+	"bytes"
+	"crypto/ed25519"
 	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
 	"math/big"
+	"strings"
 	"testing"
+	"testing/iotest"
+
+	"tss-crypto/pkg/ec"
+	"tss-crypto/pkg/mod"
 )
 
 func TestSplitSecret(t *testing.T) {
@@ -90,6 +100,352 @@ func TestSplitSecret(t *testing.T) {
 			t.Error("应该返回错误当 indices 长度 < threshold")
 		}
 	})
+
+	t.Run("重复 indices", func(t *testing.T) {
+		dupIndices := []Index{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(2)}
+		_, _, err := SplitSecret(curve, threshold, secret, dupIndices)
+		if err == nil {
+			t.Fatal("应该返回错误当 indices 有重复")
+		}
+		_, checkErr := CheckIndices(curve, dupIndices)
+		if checkErr == nil || err.Error() != checkErr.Error() {
+			t.Errorf("错误应该与 CheckIndices 一致: 期望 %v, 得到 %v", checkErr, err)
+		}
+	})
+
+	t.Run("N 的倍数作为 index", func(t *testing.T) {
+		N := curve.Params().N
+		zeroIndices := []Index{big.NewInt(1), big.NewInt(2), new(big.Int).Lsh(N, 1)}
+		_, _, err := SplitSecret(curve, threshold, secret, zeroIndices)
+		if err == nil {
+			t.Fatal("应该返回错误当 index mod N 为 0")
+		}
+		_, checkErr := CheckIndices(curve, zeroIndices)
+		if checkErr == nil || err.Error() != checkErr.Error() {
+			t.Errorf("错误应该与 CheckIndices 一致: 期望 %v, 得到 %v", checkErr, err)
+		}
+	})
+
+	t.Run("使用规范化后的 indices", func(t *testing.T) {
+		N := curve.Params().N
+		largeIndices := []Index{big.NewInt(1), big.NewInt(2), new(big.Int).Add(N, big.NewInt(3))}
+		commit, shares, err := SplitSecret(curve, threshold, secret, largeIndices)
+		if err != nil {
+			t.Fatalf("SplitSecret 失败: %v", err)
+		}
+		if shares[2].Index.Cmp(big.NewInt(3)) != 0 {
+			t.Errorf("share[2].Index 应该被规范化为 3, 得到 %v", shares[2].Index)
+		}
+		for i, share := range shares {
+			if !share.Verify(curve, commit) {
+				t.Errorf("share[%d] 应该验证通过", i)
+			}
+		}
+	})
+}
+
+func TestSplitSecretDeterministic(t *testing.T) {
+	curve := elliptic.P256()
+	indices, _ := SequentialIndices(curve, 5)
+	secret := big.NewInt(424242)
+	seed := []byte("dealer-1 seed || transcript hash")
+
+	commit, shares, err := SplitSecretDeterministic(curve, 3, secret, indices, seed)
+	if err != nil {
+		t.Fatalf("SplitSecretDeterministic 失败: %v", err)
+	}
+
+	t.Run("相同输入可复现", func(t *testing.T) {
+		commit2, shares2, err := SplitSecretDeterministic(curve, 3, secret, indices, seed)
+		if err != nil {
+			t.Fatalf("SplitSecretDeterministic 失败: %v", err)
+		}
+		if !commit.Equal(commit2) {
+			t.Error("相同 seed 应该得到相同承诺")
+		}
+		for i := range shares {
+			if shares[i].Value.Cmp(shares2[i].Value) != 0 {
+				t.Errorf("share[%d] 应该相同", i)
+			}
+		}
+	})
+
+	t.Run("份额有效且 a_0 = secret", func(t *testing.T) {
+		for i, s := range shares {
+			if !s.Verify(curve, commit) {
+				t.Errorf("share[%d] 验证失败", i)
+			}
+		}
+		if !commit.Coeffs[0].Equal(ec.ScalarBaseMult(curve, secret)) {
+			t.Error("C_0 应该等于 secret·G")
+		}
+		got, err := Reconstruct(curve, 3, shares[:3])
+		if err != nil || got.Cmp(secret) != 0 {
+			t.Errorf("重建结果应该是 %v, 得到 %v, %v", secret, got, err)
+		}
+	})
+
+	t.Run("不同 seed 或 secret 得到不同多项式", func(t *testing.T) {
+		other, _, err := SplitSecretDeterministic(curve, 3, secret, indices, []byte("another seed"))
+		if err != nil {
+			t.Fatalf("SplitSecretDeterministic 失败: %v", err)
+		}
+		if other.Coeffs[1].Equal(commit.Coeffs[1]) || other.Coeffs[2].Equal(commit.Coeffs[2]) {
+			t.Error("不同 seed 的高次系数应该不同")
+		}
+		other, _, _ = SplitSecretDeterministic(curve, 3, big.NewInt(1), indices, seed)
+		if other.Coeffs[1].Equal(commit.Coeffs[1]) {
+			t.Error("不同 secret 的高次系数应该不同")
+		}
+	})
+
+	t.Run("空 seed", func(t *testing.T) {
+		if _, _, err := SplitSecretDeterministic(curve, 3, secret, indices, nil); err == nil {
+			t.Error("应该返回错误当 seed 为空")
+		}
+	})
+}
+
+func TestRegenerateShare(t *testing.T) {
+	curve := elliptic.P256()
+	indices, _ := SequentialIndices(curve, 5)
+	secret := big.NewInt(424242)
+	seed := []byte("backup seed")
+	const threshold = 3
+
+	commit, shares, err := SplitSecretDeterministic(curve, threshold, secret, indices, seed)
+	if err != nil {
+		t.Fatalf("SplitSecretDeterministic 失败: %v", err)
+	}
+
+	t.Run("补发丢失的份额", func(t *testing.T) {
+		lost := shares[2]
+		regen, err := RegenerateShare(curve, threshold, secret, seed, lost.Index)
+		if err != nil {
+			t.Fatalf("RegenerateShare 失败: %v", err)
+		}
+		if regen.Index.Cmp(lost.Index) != 0 || regen.Value.Cmp(lost.Value) != 0 || regen.Threshold != lost.Threshold {
+			t.Error("补发的份额应该与原份额完全相同")
+		}
+		if !regen.Verify(curve, commit) {
+			t.Error("补发的份额应该对原承诺验证通过")
+		}
+
+		// 与剩余份额一起仍能恢复 secret
+		got, err := Reconstruct(curve, threshold, Shares{shares[0], regen, shares[4]})
+		if err != nil || got.Cmp(secret) != 0 {
+			t.Errorf("重建结果应该是 %v, 得到 %v, %v", secret, got, err)
+		}
+	})
+
+	t.Run("为新下标派生份额", func(t *testing.T) {
+		extra, err := RegenerateShare(curve, threshold, secret, seed, big.NewInt(42))
+		if err != nil {
+			t.Fatalf("RegenerateShare 失败: %v", err)
+		}
+		if !extra.Verify(curve, commit) {
+			t.Error("新下标的份额也应该对原承诺验证通过")
+		}
+	})
+
+	t.Run("参数不一致得到其他多项式", func(t *testing.T) {
+		wrongSeed, err := RegenerateShare(curve, threshold, secret, []byte("other"), shares[0].Index)
+		if err != nil {
+			t.Fatalf("RegenerateShare 失败: %v", err)
+		}
+		if wrongSeed.Verify(curve, commit) {
+			t.Error("seed 不同时不应该验证通过")
+		}
+		wrongT, err := RegenerateShare(curve, threshold+1, secret, seed, shares[0].Index)
+		if err != nil {
+			t.Fatalf("RegenerateShare 失败: %v", err)
+		}
+		if wrongT.Verify(curve, commit) {
+			t.Error("threshold 不同时不应该验证通过")
+		}
+	})
+
+	t.Run("非法参数", func(t *testing.T) {
+		if _, err := RegenerateShare(nil, threshold, secret, seed, indices[0]); !errors.Is(err, ErrNilCurve) {
+			t.Errorf("应该返回 ErrNilCurve, 得到 %v", err)
+		}
+		if _, err := RegenerateShare(curve, threshold, secret, nil, indices[0]); err == nil {
+			t.Error("应该返回错误当 seed 为空")
+		}
+		if _, err := RegenerateShare(curve, threshold, nil, seed, indices[0]); err == nil {
+			t.Error("应该返回错误当 secret 为 nil")
+		}
+		if _, err := RegenerateShare(curve, 0, secret, seed, indices[0]); !errors.Is(err, ErrThresholdTooSmall) {
+			t.Errorf("应该返回 ErrThresholdTooSmall, 得到 %v", err)
+		}
+		if _, err := RegenerateShare(curve, threshold, secret, seed, curve.Params().N); !errors.Is(err, ErrZeroIndex) {
+			t.Errorf("应该返回 ErrZeroIndex, 得到 %v", err)
+		}
+		if _, err := RegenerateShare(curve, threshold, secret, seed, nil); err == nil {
+			t.Error("应该返回错误当 index 为 nil")
+		}
+	})
+}
+
+// countingReader 从确定性的 SHA-256 计数器流读取，并记录读取的字节数
+type countingReader struct {
+	seed    []byte
+	counter uint64
+	buf     []byte
+	n       int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	for len(r.buf) < len(p) {
+		block := sha256.Sum256(append(r.seed, byte(r.counter>>8), byte(r.counter)))
+		r.buf = append(r.buf, block[:]...)
+		r.counter++
+	}
+	copy(p, r.buf)
+	r.buf = r.buf[len(p):]
+	r.n += len(p)
+	return len(p), nil
+}
+
+func TestSplitSecretWithReader(t *testing.T) {
+	curve := elliptic.P256()
+	secret := big.NewInt(8642)
+	indices := []Index{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4)}
+
+	t.Run("只从注入的随机源读取", func(t *testing.T) {
+		r1 := &countingReader{seed: []byte("drbg")}
+		r2 := &countingReader{seed: []byte("drbg")}
+		c1, s1, err := SplitSecretWithReader(r1, curve, 3, secret, indices)
+		if err != nil {
+			t.Fatalf("SplitSecretWithReader 失败: %v", err)
+		}
+		c2, s2, err := SplitSecretWithReader(r2, curve, 3, secret, indices)
+		if err != nil {
+			t.Fatalf("SplitSecretWithReader 失败: %v", err)
+		}
+		if r1.n == 0 {
+			t.Fatal("应该从注入的随机源读取")
+		}
+		// 相同的随机流得到相同的输出，说明没有其他随机源参与
+		if !c1.Equal(c2) || r1.n != r2.n {
+			t.Error("相同随机流应该得到相同的承诺")
+		}
+		for i := range s1 {
+			if s1[i].Value.Cmp(s2[i].Value) != 0 {
+				t.Errorf("份额 %d 应该相同", i)
+			}
+			if !s1[i].Verify(curve, c1) {
+				t.Errorf("份额 %d 应该通过验证", i)
+			}
+		}
+	})
+
+	t.Run("随机源出错", func(t *testing.T) {
+		failing := iotest.ErrReader(errors.New("drbg failure"))
+		if _, _, err := SplitSecretWithReader(failing, curve, 3, secret, indices); err == nil {
+			t.Error("应该返回错误当随机源失败")
+		}
+	})
+
+	t.Run("nil 使用默认随机源", func(t *testing.T) {
+		commit, shares, err := SplitSecretWithReader(nil, curve, 3, secret, indices)
+		if err != nil {
+			t.Fatalf("SplitSecretWithReader 失败: %v", err)
+		}
+		if !shares[0].Verify(curve, commit) {
+			t.Error("份额应该通过验证")
+		}
+	})
+}
+
+func TestEvaluateShare(t *testing.T) {
+	curve := elliptic.P256()
+	N := curve.Params().N
+	threshold := 3
+
+	poly := make([]*big.Int, threshold)
+	poly[0] = big.NewInt(31337)
+	for i := 1; i < threshold; i++ {
+		a, err := rand.Int(rand.Reader, N)
+		if err != nil {
+			t.Fatalf("生成随机系数失败: %v", err)
+		}
+		poly[i] = a
+	}
+
+	indices := []Index{big.NewInt(1), big.NewInt(2), big.NewInt(3)}
+	commit, shares, err := SplitSecretWithPolynomial(curve, poly, indices)
+	if err != nil {
+		t.Fatalf("SplitSecretWithPolynomial 失败: %v", err)
+	}
+
+	t.Run("与 SplitSecretWithPolynomial 的份额一致", func(t *testing.T) {
+		for i, share := range shares {
+			single, err := EvaluateShare(curve, poly, share.Index)
+			if err != nil {
+				t.Fatalf("EvaluateShare 失败: %v", err)
+			}
+			if single.Value.Cmp(share.Value) != 0 || single.Threshold != share.Threshold {
+				t.Errorf("share[%d] 不一致", i)
+			}
+		}
+	})
+
+	t.Run("新参与方的份额对原承诺验证通过", func(t *testing.T) {
+		newShare, err := EvaluateShare(curve, poly, big.NewInt(7))
+		if err != nil {
+			t.Fatalf("EvaluateShare 失败: %v", err)
+		}
+		if !newShare.Verify(curve, commit) {
+			t.Error("新份额应该验证通过")
+		}
+
+		// 新份额可以参与重建
+		reconstructed, err := Reconstruct(curve, threshold, Shares{shares[0], shares[2], newShare})
+		if err != nil {
+			t.Fatalf("Reconstruct 失败: %v", err)
+		}
+		if reconstructed.Cmp(poly[0]) != 0 {
+			t.Errorf("恢复的 secret 应该是 %v, 得到 %v", poly[0], reconstructed)
+		}
+	})
+
+	t.Run("空多项式", func(t *testing.T) {
+		_, _, err := SplitSecretWithPolynomial(curve, nil, indices)
+		if err == nil {
+			t.Error("应该返回错误当多项式为空")
+		}
+		if _, err := EvaluateShare(curve, nil, big.NewInt(1)); !errors.Is(err, ErrThresholdTooSmall) {
+			t.Errorf("应该返回 ErrThresholdTooSmall, 得到 %v", err)
+		}
+	})
+
+	t.Run("非法参数", func(t *testing.T) {
+		if _, err := EvaluateShare(nil, poly, big.NewInt(1)); !errors.Is(err, ErrNilCurve) {
+			t.Errorf("应该返回 ErrNilCurve, 得到 %v", err)
+		}
+		for _, idx := range []Index{big.NewInt(0), new(big.Int).Set(N), new(big.Int).Neg(N)} {
+			if _, err := EvaluateShare(curve, poly, idx); !errors.Is(err, ErrZeroIndex) {
+				t.Errorf("下标 %v: 应该返回 ErrZeroIndex, 得到 %v", idx, err)
+			}
+		}
+		if _, err := EvaluateShare(curve, poly, nil); err == nil {
+			t.Error("应该返回错误当下标为 nil")
+		}
+		if _, err := EvaluateShare(curve, []*big.Int{big.NewInt(1), nil}, big.NewInt(1)); err == nil {
+			t.Error("应该返回错误当系数为 nil")
+		}
+	})
+
+	t.Run("下标取 mod N", func(t *testing.T) {
+		share, err := EvaluateShare(curve, poly, new(big.Int).Add(N, big.NewInt(2)))
+		if err != nil {
+			t.Fatalf("EvaluateShare 失败: %v", err)
+		}
+		if share.Index.Cmp(big.NewInt(2)) != 0 || share.Value.Cmp(shares[1].Value) != 0 {
+			t.Error("下标 N+2 应该得到与下标 2 相同的份额")
+		}
+	})
 }
 
 func TestReconstruct(t *testing.T) {
@@ -202,6 +558,361 @@ func TestReconstruct(t *testing.T) {
 			t.Errorf("恢复的 secret 应该是 %v, 得到 %v", secret, reconstructed)
 		}
 	})
+
+	t.Run("恰好 threshold 个 shares 但其中一个为 nil", func(t *testing.T) {
+		sharesWithNil := make(Shares, threshold)
+		copy(sharesWithNil, shares[:threshold])
+		sharesWithNil[1] = nil
+		_, err := Reconstruct(curve, threshold, sharesWithNil)
+		if !errors.Is(err, ErrNotEnoughShares) {
+			t.Fatalf("应该返回 ErrNotEnoughShares, 得到 %v", err)
+		}
+		if !strings.Contains(err.Error(), "non-nil") {
+			t.Errorf("错误信息应该指出非 nil 份额不足, 得到 %q", err)
+		}
+	})
+
+	t.Run("threshold 字段不匹配", func(t *testing.T) {
+		mismatched := Shares{shares[0], shares[1], &Share{Index: shares[2].Index, Value: shares[2].Value, Threshold: threshold + 1}}
+		_, err := Reconstruct(curve, threshold, mismatched)
+		if !errors.Is(err, ErrNotEnoughShares) {
+			t.Fatalf("应该返回 ErrNotEnoughShares, 得到 %v", err)
+		}
+		if strings.Contains(err.Error(), "non-nil") {
+			t.Errorf("错误信息不应该归咎于 nil 份额, 得到 %q", err)
+		}
+	})
+}
+
+func TestReconstructAt(t *testing.T) {
+	curve := elliptic.P256()
+	secret := big.NewInt(24680)
+	threshold := 3
+	indices := []Index{
+		big.NewInt(1),
+		big.NewInt(2),
+		big.NewInt(3),
+		big.NewInt(4),
+		big.NewInt(5),
+	}
+
+	_, shares, err := SplitSecret(curve, threshold, secret, indices)
+	if err != nil {
+		t.Fatalf("SplitSecret 失败: %v", err)
+	}
+
+	t.Run("x = 0 与 Reconstruct 一致", func(t *testing.T) {
+		got, err := ReconstructAt(curve, threshold, shares, big.NewInt(0))
+		if err != nil {
+			t.Fatalf("ReconstructAt 失败: %v", err)
+		}
+		expected, err := Reconstruct(curve, threshold, shares)
+		if err != nil {
+			t.Fatalf("Reconstruct 失败: %v", err)
+		}
+		if got.Cmp(expected) != 0 || got.Cmp(secret) != 0 {
+			t.Errorf("期望 %v, 得到 %v", expected, got)
+		}
+	})
+
+	t.Run("在 share 自身的 index 处返回该 share 的值", func(t *testing.T) {
+		// 用前 3 个 share 插值，检查所有 5 个 index 处的值
+		for i, share := range shares {
+			got, err := ReconstructAt(curve, threshold, shares[:threshold], share.Index)
+			if err != nil {
+				t.Fatalf("ReconstructAt 失败: %v", err)
+			}
+			if got.Cmp(share.Value) != 0 {
+				t.Errorf("share[%d]: 期望 %v, 得到 %v", i, share.Value, got)
+			}
+		}
+	})
+}
+
+func TestReconstructForce(t *testing.T) {
+	curve := elliptic.P256()
+	secret := big.NewInt(123456)
+	threshold := 3
+	indices := []Index{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4), big.NewInt(5)}
+	_, shares, err := SplitSecret(curve, threshold, secret, indices)
+	if err != nil {
+		t.Fatalf("SplitSecret 失败: %v", err)
+	}
+
+	// zeroed 的 Threshold 字段全部清零，模拟 resharing 后记录不一致的 share
+	zeroed := make(Shares, len(shares))
+	for i, s := range shares {
+		zeroed[i] = &Share{Index: s.Index, Value: s.Value, Threshold: 0}
+	}
+
+	t.Run("Threshold 字段清零后仍能恢复", func(t *testing.T) {
+		if _, err := Reconstruct(curve, threshold, zeroed); !errors.Is(err, ErrNotEnoughShares) {
+			t.Fatalf("前提: Reconstruct 应该拒绝这些 share, 得到 %v", err)
+		}
+		got, err := ReconstructForce(curve, threshold, zeroed)
+		if err != nil {
+			t.Fatalf("ReconstructForce 失败: %v", err)
+		}
+		if got.Cmp(secret) != 0 {
+			t.Errorf("恢复的 secret 不正确: 得到 %v, 期望 %v", got, secret)
+		}
+	})
+
+	t.Run("只使用前 threshold 个 share", func(t *testing.T) {
+		// 第 threshold+1 个 share 被篡改也不影响结果
+		tampered := append(Shares{nil}, zeroed...)
+		tampered[threshold+1] = &Share{Index: zeroed[threshold].Index, Value: big.NewInt(1)}
+		got, err := ReconstructForce(curve, threshold, tampered)
+		if err != nil {
+			t.Fatalf("ReconstructForce 失败: %v", err)
+		}
+		if got.Cmp(secret) != 0 {
+			t.Error("跳过 nil 后应该只用前 threshold 个 share")
+		}
+	})
+
+	t.Run("threshold 给错时静默得到错误结果", func(t *testing.T) {
+		got, err := ReconstructForce(curve, threshold-1, zeroed)
+		if err != nil {
+			t.Fatalf("ReconstructForce 失败: %v", err)
+		}
+		if got.Cmp(secret) == 0 {
+			t.Error("插值次数过低时不应该恰好得到正确的 secret")
+		}
+	})
+
+	t.Run("share 不足", func(t *testing.T) {
+		if _, err := ReconstructForce(curve, threshold, zeroed[:threshold-1]); !errors.Is(err, ErrNotEnoughShares) {
+			t.Errorf("应该返回 ErrNotEnoughShares, 得到 %v", err)
+		}
+		if _, err := ReconstructForce(curve, threshold, Shares{nil, nil, nil}); !errors.Is(err, ErrNotEnoughShares) {
+			t.Errorf("全为 nil 时应该返回 ErrNotEnoughShares, 得到 %v", err)
+		}
+	})
+
+	t.Run("非法参数", func(t *testing.T) {
+		if _, err := ReconstructForce(nil, threshold, zeroed); !errors.Is(err, ErrNilCurve) {
+			t.Errorf("应该返回 ErrNilCurve, 得到 %v", err)
+		}
+		if _, err := ReconstructForce(curve, 0, zeroed); !errors.Is(err, ErrThresholdTooSmall) {
+			t.Errorf("应该返回 ErrThresholdTooSmall, 得到 %v", err)
+		}
+		dup := Shares{zeroed[0], zeroed[0], zeroed[1]}
+		if _, err := ReconstructForce(curve, threshold, dup); err == nil {
+			t.Error("重复下标应该返回错误")
+		}
+	})
+}
+
+func TestReconstructWithPublic(t *testing.T) {
+	curve := elliptic.P256()
+	secret := big.NewInt(97531)
+	threshold := 3
+	indices := []Index{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4)}
+
+	commit, shares, err := SplitSecret(curve, threshold, secret, indices)
+	if err != nil {
+		t.Fatalf("SplitSecret 失败: %v", err)
+	}
+
+	t.Run("公钥等于 C_0", func(t *testing.T) {
+		got, pub, err := ReconstructWithPublic(curve, threshold, shares[1:])
+		if err != nil {
+			t.Fatalf("ReconstructWithPublic 失败: %v", err)
+		}
+		if got.Cmp(secret) != 0 {
+			t.Errorf("恢复的 secret 应该是 %v, 得到 %v", secret, got)
+		}
+		if !pub.Equal(commit.Coeffs[0]) {
+			t.Error("返回的公钥应该等于 commit.Coeffs[0]")
+		}
+	})
+
+	t.Run("份额不足", func(t *testing.T) {
+		_, _, err := ReconstructWithPublic(curve, threshold, shares[:threshold-1])
+		if !errors.Is(err, ErrNotEnoughShares) {
+			t.Errorf("应该返回 ErrNotEnoughShares, 得到 %v", err)
+		}
+	})
+}
+
+func TestFinalize(t *testing.T) {
+	curve := elliptic.P256()
+	secret := big.NewInt(20240601)
+	threshold := 3
+	indices := []Index{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4), big.NewInt(5)}
+
+	commit, shares, err := SplitSecret(curve, threshold, secret, indices)
+	if err != nil {
+		t.Fatalf("SplitSecret 失败: %v", err)
+	}
+	corrupt := func(s *Share) *Share {
+		return &Share{Index: s.Index, Value: new(big.Int).Add(s.Value, big.NewInt(1)), Threshold: s.Threshold}
+	}
+
+	t.Run("跳过无效份额后恢复", func(t *testing.T) {
+		mixed := Shares{corrupt(shares[0]), shares[1], nil, shares[2], corrupt(shares[3]), shares[4]}
+		got, pub, err := Finalize(curve, threshold, mixed, commit)
+		if err != nil {
+			t.Fatalf("Finalize 失败: %v", err)
+		}
+		if got.Cmp(secret) != 0 {
+			t.Errorf("恢复的 secret 应该是 %v, 得到 %v", secret, got)
+		}
+		if !pub.Equal(commit.Coeffs[0]) {
+			t.Error("返回的公钥应该等于 C_0")
+		}
+	})
+
+	t.Run("有效份额不足", func(t *testing.T) {
+		mixed := Shares{corrupt(shares[0]), shares[1], corrupt(shares[2]), shares[3], corrupt(shares[4])}
+		if _, _, err := Finalize(curve, threshold, mixed, commit); !errors.Is(err, ErrNotEnoughShares) {
+			t.Errorf("应该返回 ErrNotEnoughShares, 得到 %v", err)
+		}
+	})
+
+	t.Run("无效承诺", func(t *testing.T) {
+		if _, _, err := Finalize(curve, threshold, shares, nil); !errors.Is(err, ErrInvalidCommitment) {
+			t.Errorf("应该返回 ErrInvalidCommitment, 得到 %v", err)
+		}
+	})
+}
+
+func TestInterpolatePoints(t *testing.T) {
+	for _, curve := range []elliptic.Curve{elliptic.P256(), ec.Ed25519()} {
+		t.Run(curve.Params().Name, func(t *testing.T) {
+			secret := big.NewInt(13579)
+			indices := []Index{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4), big.NewInt(5)}
+			_, shares, err := SplitSecret(curve, 3, secret, indices)
+			if err != nil {
+				t.Fatalf("SplitSecret 失败: %v", err)
+			}
+
+			// 只使用公开份额 Y_i = s_i·G
+			subset := []*Share{shares[4], shares[1], shares[2]}
+			idx := make([]Index, len(subset))
+			pts := make([]*ec.Point, len(subset))
+			for i, s := range subset {
+				idx[i] = s.Index
+				pts[i] = ec.ScalarBaseMult(curve, s.Value)
+			}
+
+			got, err := InterpolatePoints(curve, idx, pts)
+			if err != nil {
+				t.Fatalf("InterpolatePoints 失败: %v", err)
+			}
+			if !got.Equal(ec.ScalarBaseMult(curve, secret)) {
+				t.Error("插值结果应该等于 secret·G")
+			}
+
+			t.Run("无效输入", func(t *testing.T) {
+				if _, err := InterpolatePoints(curve, idx[:2], pts); err == nil {
+					t.Error("应该返回错误当下标与点数量不一致")
+				}
+				if _, err := InterpolatePoints(curve, nil, nil); !errors.Is(err, ErrNotEnoughShares) {
+					t.Errorf("应该返回 ErrNotEnoughShares, 得到 %v", err)
+				}
+				dup := []Index{idx[0], idx[0], idx[1]}
+				if _, err := InterpolatePoints(curve, dup, pts); !errors.Is(err, ErrDuplicateIndex) {
+					t.Errorf("应该返回 ErrDuplicateIndex, 得到 %v", err)
+				}
+				withNil := []*ec.Point{pts[0], nil, pts[2]}
+				if _, err := InterpolatePoints(curve, idx, withNil); err == nil {
+					t.Error("应该返回错误当点为 nil")
+				}
+			})
+		})
+	}
+}
+
+func TestToAdditiveShares(t *testing.T) {
+	curve := elliptic.P256()
+	N := curve.Params().N
+	indices, _ := SequentialIndices(curve, 5)
+	secret, _ := rand.Int(rand.Reader, N)
+	_, shares, err := SplitSecret(curve, 3, secret, indices)
+	if err != nil {
+		t.Fatalf("SplitSecret 失败: %v", err)
+	}
+
+	t.Run("加法份额之和等于秘密", func(t *testing.T) {
+		for _, subset := range []Shares{shares[:3], shares[2:], {shares[0], shares[2], shares[4]}, shares} {
+			additive, err := ToAdditiveShares(curve, 3, subset)
+			if err != nil {
+				t.Fatalf("ToAdditiveShares 失败: %v", err)
+			}
+			if len(additive) != len(subset) {
+				t.Fatalf("应该有 %d 个加法份额, 得到 %d", len(subset), len(additive))
+			}
+			sum := new(big.Int)
+			for _, s := range subset {
+				w, ok := additive[s.Index.String()]
+				if !ok {
+					t.Fatalf("缺少下标 %v 的加法份额", s.Index)
+				}
+				sum = mod.ModAdd(sum, w, N)
+			}
+			reconstructed, _ := Reconstruct(curve, 3, subset)
+			if sum.Cmp(reconstructed) != 0 || sum.Cmp(secret) != 0 {
+				t.Error("加法份额之和应该等于重建出的秘密")
+			}
+		}
+	})
+
+	t.Run("参数错误", func(t *testing.T) {
+		if _, err := ToAdditiveShares(curve, 3, shares[:2]); !errors.Is(err, ErrNotEnoughShares) {
+			t.Errorf("份额不足时应该返回 ErrNotEnoughShares, 得到 %v", err)
+		}
+		if _, err := ToAdditiveShares(curve, 3, Shares{shares[0], shares[1], shares[1]}); !errors.Is(err, ErrDuplicateIndex) {
+			t.Errorf("下标重复时应该返回 ErrDuplicateIndex, 得到 %v", err)
+		}
+		if _, err := ToAdditiveShares(curve, 3, Shares{shares[0], nil, shares[2]}); err == nil {
+			t.Error("应该返回错误当份额为 nil")
+		}
+		if _, err := ToAdditiveShares(nil, 3, shares); !errors.Is(err, ErrNilCurve) {
+			t.Errorf("应该返回 ErrNilCurve, 得到 %v", err)
+		}
+	})
+}
+
+func TestLagrangeCoefficients(t *testing.T) {
+	curve := elliptic.P256()
+	N := curve.Params().N
+	indices, _ := SequentialIndices(curve, 5)
+	secret, _ := rand.Int(rand.Reader, N)
+	_, shares, err := SplitSecret(curve, 3, secret, indices)
+	if err != nil {
+		t.Fatalf("SplitSecret 失败: %v", err)
+	}
+
+	t.Run("加权和等于插值结果", func(t *testing.T) {
+		subset := Shares{shares[0], shares[2], shares[4]}
+		x := big.NewInt(11)
+		lambdas, err := LagrangeCoefficients(curve, []Index{subset[0].Index, subset[1].Index, subset[2].Index}, x)
+		if err != nil {
+			t.Fatalf("LagrangeCoefficients 失败: %v", err)
+		}
+		sum := new(big.Int)
+		for i, s := range subset {
+			sum = mod.ModAdd(sum, mod.ModMul(lambdas[i], s.Value, N), N)
+		}
+		want, err := ReconstructAt(curve, 3, subset, x)
+		if err != nil {
+			t.Fatalf("ReconstructAt 失败: %v", err)
+		}
+		if sum.Cmp(want) != 0 {
+			t.Error("Σ λ_i·s_i 应该等于 f(x)")
+		}
+	})
+
+	t.Run("参数错误", func(t *testing.T) {
+		if _, err := LagrangeCoefficients(nil, indices, big.NewInt(0)); !errors.Is(err, ErrNilCurve) {
+			t.Errorf("应该返回 ErrNilCurve, 得到 %v", err)
+		}
+		if _, err := LagrangeCoefficients(curve, []Index{big.NewInt(1), big.NewInt(1)}, big.NewInt(0)); !errors.Is(err, ErrDuplicateIndex) {
+			t.Errorf("应该返回 ErrDuplicateIndex, 得到 %v", err)
+		}
+	})
 }
 
 func TestShare_Verify(t *testing.T) {
@@ -274,6 +985,28 @@ func TestShare_Verify(t *testing.T) {
 		}
 	})
 
+	t.Run("分别构造的同一曲线", func(t *testing.T) {
+		// 复制一份 P-256 参数，得到与 elliptic.P256() 不同的实例
+		params := *curve.Params()
+		sameCurve := &params
+		if elliptic.Curve(sameCurve) == curve {
+			t.Fatal("测试前提：两个曲线实例应该不同")
+		}
+		for i, share := range shares {
+			if !share.Verify(sameCurve, commit) {
+				t.Errorf("share %d 在参数相同的曲线实例上应该验证通过", i)
+			}
+		}
+
+		// 参数不同（换了基点）则不一致
+		otherGen := *curve.Params()
+		g2 := ec.ScalarBaseMult(curve, big.NewInt(2))
+		otherGen.Gx, otherGen.Gy = g2.X, g2.Y
+		if shares[0].Verify(&otherGen, commit) {
+			t.Error("基点不同的曲线不应该验证通过")
+		}
+	})
+
 	t.Run("threshold 不匹配", func(t *testing.T) {
 		wrongShare := &Share{
 			Index:     shares[0].Index,
@@ -308,6 +1041,56 @@ func TestShare_Verify(t *testing.T) {
 	})
 }
 
+func TestVerifyWithPublicShare(t *testing.T) {
+	curve := elliptic.P256()
+	indices := []Index{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4)}
+	commit, shares, err := SplitSecret(curve, 3, big.NewInt(4242), indices)
+	if err != nil {
+		t.Fatalf("SplitSecret 失败: %v", err)
+	}
+
+	t.Run("与 Verify 结果一致", func(t *testing.T) {
+		for i, share := range shares {
+			Y := ec.ScalarBaseMult(curve, share.Value)
+			if got, want := VerifyWithPublicShare(curve, commit, share.Index, Y), share.Verify(curve, commit); got != want || !got {
+				t.Errorf("share[%d]: VerifyWithPublicShare = %v, Verify = %v", i, got, want)
+			}
+		}
+	})
+
+	t.Run("错误的公开份额", func(t *testing.T) {
+		wrong := ec.ScalarBaseMult(curve, new(big.Int).Add(shares[0].Value, big.NewInt(1)))
+		if VerifyWithPublicShare(curve, commit, shares[0].Index, wrong) {
+			t.Error("错误的公开份额不应该验证通过")
+		}
+		// 其他参与方的公开份额
+		other := ec.ScalarBaseMult(curve, shares[1].Value)
+		if VerifyWithPublicShare(curve, commit, shares[0].Index, other) {
+			t.Error("其他下标的公开份额不应该验证通过")
+		}
+	})
+
+	t.Run("非法参数", func(t *testing.T) {
+		Y := ec.ScalarBaseMult(curve, shares[0].Value)
+		if VerifyWithPublicShare(curve, nil, shares[0].Index, Y) {
+			t.Error("nil commit 不应该验证通过")
+		}
+		if VerifyWithPublicShare(curve, commit, nil, Y) {
+			t.Error("nil index 不应该验证通过")
+		}
+		if VerifyWithPublicShare(curve, commit, shares[0].Index, nil) {
+			t.Error("nil 公开份额不应该验证通过")
+		}
+		if VerifyWithPublicShare(elliptic.P224(), commit, shares[0].Index, Y) {
+			t.Error("曲线不一致时不应该验证通过")
+		}
+		otherCurveY := ec.ScalarBaseMult(elliptic.P384(), shares[0].Value)
+		if VerifyWithPublicShare(curve, commit, shares[0].Index, otherCurveY) {
+			t.Error("公开份额不在同一曲线上时不应该验证通过")
+		}
+	})
+}
+
 func TestCheckIndices(t *testing.T) {
 	curve := elliptic.P256()
 	N := curve.Params().N
@@ -401,6 +1184,187 @@ func TestCheckIndices(t *testing.T) {
 	})
 }
 
+func TestValidateThreshold(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold int
+		n         int
+		target    error
+	}{
+		{"threshold = 0", 0, 5, ErrThresholdTooSmall},
+		{"threshold 为负", -1, 5, ErrThresholdTooSmall},
+		{"threshold = n+1", 6, 5, ErrNotEnoughShares},
+		{"n = 0", 1, 0, ErrNotEnoughShares},
+		{"threshold = 1", 1, 5, nil},
+		{"threshold = n", 5, 5, nil},
+		{"1 < threshold < n", 3, 5, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateThreshold(tt.threshold, tt.n)
+			if tt.target == nil {
+				if err != nil {
+					t.Errorf("不应该返回错误, 得到 %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.target) {
+				t.Errorf("errors.Is(%v, %v) 应该为 true", err, tt.target)
+			}
+		})
+	}
+
+	t.Run("SplitSecret 使用相同的检查", func(t *testing.T) {
+		indices, _ := SequentialIndices(elliptic.P256(), 3)
+		_, _, err := SplitSecret(elliptic.P256(), 4, big.NewInt(1), indices)
+		expected := ValidateThreshold(4, 3)
+		if err == nil || err.Error() != expected.Error() {
+			t.Errorf("错误应该与 ValidateThreshold 一致: 期望 %v, 得到 %v", expected, err)
+		}
+	})
+}
+
+func TestCheckIndicesMapped(t *testing.T) {
+	curve := elliptic.P256()
+	N := curve.Params().N
+
+	large := new(big.Int).Add(N, big.NewInt(9))
+	indices := []Index{big.NewInt(1), large, big.NewInt(4)}
+
+	normalized, originals, err := CheckIndicesMapped(curve, indices)
+	if err != nil {
+		t.Fatalf("CheckIndicesMapped 失败: %v", err)
+	}
+
+	t.Run("规范化结果与 CheckIndices 一致", func(t *testing.T) {
+		expected, _ := CheckIndices(curve, indices)
+		for i := range expected {
+			if normalized[i].Cmp(expected[i]) != 0 {
+				t.Errorf("normalized[%d] 应该是 %v, 得到 %v", i, expected[i], normalized[i])
+			}
+		}
+	})
+
+	t.Run("映射恢复原始下标", func(t *testing.T) {
+		if len(originals) != len(indices) {
+			t.Fatalf("映射大小应该是 %d, 得到 %d", len(indices), len(originals))
+		}
+		orig, ok := originals["9"]
+		if !ok {
+			t.Fatal("映射中应该包含被规范化的下标 9")
+		}
+		if orig.Cmp(large) != 0 {
+			t.Errorf("下标 9 应该映射回 %v, 得到 %v", large, orig)
+		}
+		for i, norm := range normalized {
+			if originals[norm.String()] != indices[i] {
+				t.Errorf("normalized[%d] 没有映射回 indices[%d]", i, i)
+			}
+		}
+	})
+
+	t.Run("错误与 CheckIndices 一致", func(t *testing.T) {
+		_, _, err := CheckIndicesMapped(curve, []Index{big.NewInt(9), large})
+		if !errors.Is(err, ErrDuplicateIndex) {
+			t.Errorf("应该返回 ErrDuplicateIndex, 得到 %v", err)
+		}
+	})
+}
+
+func TestIndexHelpers(t *testing.T) {
+	curve := elliptic.P256()
+
+	t.Run("SequentialIndices(5) 为 1..5", func(t *testing.T) {
+		indices, err := SequentialIndices(curve, 5)
+		if err != nil {
+			t.Fatalf("SequentialIndices 失败: %v", err)
+		}
+		if len(indices) != 5 {
+			t.Fatalf("长度应该是 5, 得到 %d", len(indices))
+		}
+		for i, idx := range indices {
+			if idx.Int64() != int64(i+1) {
+				t.Errorf("indices[%d] 应该是 %d, 得到 %v", i, i+1, idx)
+			}
+		}
+	})
+
+	t.Run("SequentialIndices n < 1", func(t *testing.T) {
+		if _, err := SequentialIndices(curve, 0); err == nil {
+			t.Error("应该返回错误当 n < 1")
+		}
+	})
+
+	t.Run("IndicesFromIDs", func(t *testing.T) {
+		indices, err := IndicesFromIDs(curve, []uint32{7, 3, 1 << 31})
+		if err != nil {
+			t.Fatalf("IndicesFromIDs 失败: %v", err)
+		}
+		expected := []int64{7, 3, 1 << 31}
+		for i, idx := range indices {
+			if idx.Int64() != expected[i] {
+				t.Errorf("indices[%d] 应该是 %d, 得到 %v", i, expected[i], idx)
+			}
+		}
+	})
+
+	t.Run("IndicesFromIDs 拒绝 ID 0", func(t *testing.T) {
+		if _, err := IndicesFromIDs(curve, []uint32{1, 0, 2}); !errors.Is(err, ErrZeroIndex) {
+			t.Errorf("应该返回 ErrZeroIndex, 得到 %v", err)
+		}
+	})
+
+	t.Run("IndicesFromIDs 拒绝重复 ID", func(t *testing.T) {
+		if _, err := IndicesFromIDs(curve, []uint32{4, 4}); !errors.Is(err, ErrDuplicateIndex) {
+			t.Errorf("应该返回 ErrDuplicateIndex, 得到 %v", err)
+		}
+	})
+}
+
+func TestErrors(t *testing.T) {
+	curve := elliptic.P256()
+	N := curve.Params().N
+	secret := big.NewInt(1)
+	indices := []Index{big.NewInt(1), big.NewInt(2), big.NewInt(3)}
+	_, shares, err := SplitSecret(curve, 2, secret, indices)
+	if err != nil {
+		t.Fatalf("SplitSecret 失败: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		err    error
+		target error
+	}{
+		{"SplitSecret nil curve", errOf3(SplitSecret(nil, 2, secret, indices)), ErrNilCurve},
+		{"SplitSecret threshold < 1", errOf3(SplitSecret(curve, 0, secret, indices)), ErrThresholdTooSmall},
+		{"SplitSecret indices 不足", errOf3(SplitSecret(curve, 4, secret, indices)), ErrNotEnoughShares},
+		{"SplitSecret 重复 index", errOf3(SplitSecret(curve, 2, secret, []Index{big.NewInt(1), big.NewInt(1)})), ErrDuplicateIndex},
+		{"SplitSecret 零 index", errOf3(SplitSecret(curve, 2, secret, []Index{big.NewInt(1), N})), ErrZeroIndex},
+		{"Reconstruct nil curve", errOf2(Reconstruct(nil, 2, shares)), ErrNilCurve},
+		{"Reconstruct threshold < 1", errOf2(Reconstruct(curve, 0, shares)), ErrThresholdTooSmall},
+		{"Reconstruct shares 不足", errOf2(Reconstruct(curve, 2, shares[:1])), ErrNotEnoughShares},
+		{"Reconstruct 有效 shares 不足", errOf2(Reconstruct(curve, 2, Shares{shares[0], nil})), ErrNotEnoughShares},
+		{"CheckIndices nil curve", errOf2(CheckIndices(nil, indices)), ErrNilCurve},
+		{"CheckIndices 重复 index", errOf2(CheckIndices(curve, []Index{big.NewInt(2), new(big.Int).Add(N, big.NewInt(2))})), ErrDuplicateIndex},
+		{"CheckIndices 零 index", errOf2(CheckIndices(curve, []Index{big.NewInt(0)})), ErrZeroIndex},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, tt.target) {
+				t.Errorf("errors.Is(%v, %v) 应该为 true", tt.err, tt.target)
+			}
+		})
+	}
+}
+
+// errOf2 取两返回值函数的错误
+func errOf2[T any](_ T, err error) error { return err }
+
+// errOf3 取三返回值函数的错误
+func errOf3[T, U any](_ T, _ U, err error) error { return err }
+
 func TestIntegration(t *testing.T) {
 	// 集成测试：完整的 VSS 流程
 	curve := elliptic.P256()
@@ -460,6 +1424,7 @@ func TestDifferentCurves(t *testing.T) {
 		elliptic.P256(),
 		elliptic.P384(),
 		elliptic.P521(),
+		ec.Ed25519(),
 	}
 
 	secret := big.NewInt(42)
@@ -499,6 +1464,73 @@ func TestDifferentCurves(t *testing.T) {
 	}
 }
 
+func TestEd25519(t *testing.T) {
+	curve := ec.Ed25519()
+	L := curve.Params().N
+
+	// 按 RFC 8032 从种子导出私钥标量，并对 L 取模；对应的公钥由 crypto/ed25519 给出
+	seed := make([]byte, ed25519.SeedSize)
+	if _, err := rand.Read(seed); err != nil {
+		t.Fatalf("生成种子失败: %v", err)
+	}
+	scalar, err := ec.Ed25519ScalarFromSeed(seed)
+	if err != nil {
+		t.Fatalf("导出标量失败: %v", err)
+	}
+	expectedPub := ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)
+
+	indices, _ := SequentialIndices(curve, 5)
+	commit, shares, err := SplitSecret(curve, 3, scalar, indices)
+	if err != nil {
+		t.Fatalf("SplitSecret 失败: %v", err)
+	}
+
+	t.Run("份额按 L 取模并通过验证", func(t *testing.T) {
+		for i, share := range shares {
+			if share.Value.Cmp(L) >= 0 {
+				t.Errorf("share[%d] 没有按 L 取模", i)
+			}
+			if !share.Verify(curve, commit) {
+				t.Errorf("share[%d] 验证失败", i)
+			}
+		}
+	})
+
+	t.Run("C_0 等于 Ed25519 公钥", func(t *testing.T) {
+		got, err := commit.Coeffs[0].MarshalEd25519()
+		if err != nil {
+			t.Fatalf("MarshalEd25519 失败: %v", err)
+		}
+		if !bytes.Equal(got, expectedPub) {
+			t.Error("C_0 的编码与 crypto/ed25519 公钥不一致")
+		}
+	})
+
+	t.Run("重建标量", func(t *testing.T) {
+		got, err := Reconstruct(curve, 3, Shares{shares[4], shares[1], shares[3]})
+		if err != nil {
+			t.Fatalf("Reconstruct 失败: %v", err)
+		}
+		if got.Cmp(new(big.Int).Mod(scalar, L)) != 0 {
+			t.Error("重建的标量与原标量 mod L 不一致")
+		}
+	})
+
+	t.Run("承诺编解码", func(t *testing.T) {
+		data, err := commit.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary 失败: %v", err)
+		}
+		var decoded Commitment
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary 失败: %v", err)
+		}
+		if !shares[0].Verify(curve, &decoded) {
+			t.Error("对解码后的承诺验证失败")
+		}
+	})
+}
+
 func TestLargeSecret(t *testing.T) {
 	// 测试大秘密值
 	curve := elliptic.P256()
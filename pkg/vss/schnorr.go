@@ -0,0 +1,96 @@
+package vss
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"math/big"
+
+	"tss-crypto/pkg/ec"
+	"tss-crypto/pkg/transcript"
+)
+
+// ---- 份额知识证明 ----
+
+// SchnorrProof 证明持有某个下标上的份额值 s，且 s·G 等于承诺在该下标处的点值，而不泄露 s：
+//
+//	承诺：R = k·G，k ∈ [1, N) 随机
+//	挑战：e = H(curve, index, Y, R) mod N，Y = s·G（Fiat-Shamir）
+//	响应：Z = k + e·s mod N
+//
+// 验证：Z·G == R + e·Y，其中 Y 由承诺在 index 处求值得到
+type SchnorrProof struct {
+	R *ec.Point
+	Z *big.Int
+}
+
+// ProveShareKnowledge 为 share 生成份额知识证明
+func ProveShareKnowledge(curve elliptic.Curve, share *Share) (*SchnorrProof, error) {
+	if curve == nil {
+		return nil, ErrNilCurve
+	}
+	if share == nil || share.Index == nil || share.Value == nil {
+		return nil, errors.New("share is nil")
+	}
+
+	field := ec.NewScalarField(curve)
+	k, err := field.Rand(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	R := ec.ScalarBaseMult(curve, k)
+	Y := ec.ScalarBaseMult(curve, share.Value)
+
+	e := shareChallenge(curve, share.Index, Y, R)
+	Z := field.Add(k, field.Mul(e, share.Value))
+	return &SchnorrProof{R: R, Z: Z}, nil
+}
+
+// Verify 验证证明者知道 commit 在 index 处对应的份额值
+func (p *SchnorrProof) Verify(curve elliptic.Curve, commit *Commitment, index Index) bool {
+	if p == nil || p.R == nil || p.Z == nil || index == nil {
+		return false
+	}
+	if curve == nil || commit.Validate() != nil || !ec.SameCurve(commit.Curve, curve) || !ec.SameCurve(p.R.Curve, curve) {
+		return false
+	}
+	N := curve.Params().N
+	if p.Z.Sign() < 0 || p.Z.Cmp(N) >= 0 {
+		return false
+	}
+	if new(big.Int).Mod(index, N).Sign() == 0 {
+		return false
+	}
+	if !p.R.IsOnCurve() {
+		return false
+	}
+
+	Y := commit.evaluate(index)
+	e := shareChallenge(curve, index, Y, p.R)
+
+	lhs := ec.ScalarBaseMult(curve, p.Z)
+	rhs := p.R.Add(Y.ScalarMult(e))
+	return lhs.Equal(rhs)
+}
+
+// shareChallenge 通过 transcript 计算 e = H(curve, index, Y, R) mod N
+func shareChallenge(curve elliptic.Curve, index Index, Y, R *ec.Point) *big.Int {
+	N := curve.Params().N
+	tr := transcript.New("vss-share-knowledge")
+	tr.Append("curve", []byte(curve.Params().Name))
+	tr.AppendInt("index", new(big.Int).Mod(index, N))
+	appendPoint(tr, "Y", Y)
+	appendPoint(tr, "R", R)
+	return tr.Challenge("e", N)
+}
+
+// appendPoint 写入点的仿射坐标，无穷远点写入空坐标
+func appendPoint(tr *transcript.Transcript, label string, pt *ec.Point) {
+	if pt.IsInfinity() {
+		tr.Append(label+".x", nil)
+		tr.Append(label+".y", nil)
+		return
+	}
+	tr.AppendInt(label+".x", pt.X)
+	tr.AppendInt(label+".y", pt.Y)
+}
@@ -0,0 +1,73 @@
+package vss
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"testing"
+
+	"tss-crypto/pkg/ec"
+)
+
+func TestSchnorrShareProof(t *testing.T) {
+	for _, curve := range []elliptic.Curve{elliptic.P256(), ec.Ed25519()} {
+		t.Run(curve.Params().Name, func(t *testing.T) {
+			indices := []Index{big.NewInt(1), big.NewInt(2), big.NewInt(3)}
+			commit, shares, err := SplitSecret(curve, 2, big.NewInt(424242), indices)
+			if err != nil {
+				t.Fatalf("SplitSecret 失败: %v", err)
+			}
+
+			proof, err := ProveShareKnowledge(curve, shares[0])
+			if err != nil {
+				t.Fatalf("ProveShareKnowledge 失败: %v", err)
+			}
+
+			t.Run("有效证明", func(t *testing.T) {
+				if !proof.Verify(curve, commit, shares[0].Index) {
+					t.Error("有效证明应该通过验证")
+				}
+			})
+
+			t.Run("下标不同", func(t *testing.T) {
+				if proof.Verify(curve, commit, shares[1].Index) {
+					t.Error("换成其他下标时证明应该被拒绝")
+				}
+			})
+
+			t.Run("份额值不同", func(t *testing.T) {
+				wrong := &Share{
+					Index:     shares[0].Index,
+					Value:     new(big.Int).Add(shares[0].Value, big.NewInt(1)),
+					Threshold: shares[0].Threshold,
+				}
+				bad, err := ProveShareKnowledge(curve, wrong)
+				if err != nil {
+					t.Fatalf("ProveShareKnowledge 失败: %v", err)
+				}
+				if bad.Verify(curve, commit, wrong.Index) {
+					t.Error("错误份额值的证明应该被拒绝")
+				}
+			})
+
+			t.Run("篡改响应", func(t *testing.T) {
+				tampered := &SchnorrProof{R: proof.R, Z: new(big.Int).Add(proof.Z, big.NewInt(1))}
+				tampered.Z.Mod(tampered.Z, curve.Params().N)
+				if tampered.Verify(curve, commit, shares[0].Index) {
+					t.Error("篡改 Z 后证明应该被拒绝")
+				}
+			})
+
+			t.Run("无效输入", func(t *testing.T) {
+				if (*SchnorrProof)(nil).Verify(curve, commit, shares[0].Index) {
+					t.Error("nil 证明应该被拒绝")
+				}
+				if proof.Verify(curve, nil, shares[0].Index) {
+					t.Error("nil 承诺应该被拒绝")
+				}
+				if _, err := ProveShareKnowledge(curve, nil); err == nil {
+					t.Error("应该返回错误当 share 为 nil")
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package vss
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ---- 任意模数下的 Shamir 秘密共享 ----
+
+// ErrInvalidModulus 表示共享模数不合法
+var ErrInvalidModulus = errors.New("modulus must be greater than 1")
+
+// SplitSecretShamir 在调用方给定的模数下做纯 Shamir 拆分（无 Feldman 承诺），
+// 与 SplitSecret 共用多项式求值和索引检查，只是把曲线阶换成 modulus。
+// 适用于秘密本身位于模 Paillier N 等非曲线阶的场景。
+// 插值需要对索引差求逆，modulus 应为素数；合数模数下与 modulus 不互素的索引返回 ErrIndexNotUnit
+func SplitSecretShamir(modulus *big.Int, threshold int, secret *big.Int, indices []Index) (Shares, error) {
+	if modulus == nil || modulus.Cmp(big.NewInt(1)) <= 0 {
+		return nil, ErrInvalidModulus
+	}
+	if secret == nil {
+		return nil, fmt.Errorf("secret is nil")
+	}
+	if err := ValidateThreshold(threshold, len(indices)); err != nil {
+		return nil, err
+	}
+	indices, err := checkIndices(indices, modulus)
+	if err != nil {
+		return nil, err
+	}
+
+	polynomial, err := generateRandomPolynomial(nil, threshold, new(big.Int).Mod(secret, modulus), modulus)
+	if err != nil {
+		return nil, err
+	}
+	shares := make(Shares, len(indices))
+	for i, index := range indices {
+		shares[i] = &Share{
+			Index:     index,
+			Value:     computeShare(polynomial, index, modulus),
+			Threshold: threshold,
+		}
+	}
+	return shares, nil
+}
+
+// ReconstructShamir 在模数 modulus 下使用至少 t 个 share 恢复 secret
+func ReconstructShamir(modulus *big.Int, threshold int, shares Shares) (*big.Int, error) {
+	if modulus == nil || modulus.Cmp(big.NewInt(1)) <= 0 {
+		return nil, ErrInvalidModulus
+	}
+	if threshold < 1 {
+		return nil, ErrThresholdTooSmall
+	}
+	if len(shares) < threshold {
+		return nil, fmt.Errorf("need at least %d shares to reconstruct, got %d: %w", threshold, len(shares), ErrNotEnoughShares)
+	}
+	return interpolateAt(shares, threshold, big.NewInt(0), modulus)
+}
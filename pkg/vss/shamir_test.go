@@ -0,0 +1,85 @@
+package vss
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestShamirCustomModulus(t *testing.T) {
+	// 与任何曲线无关的 256 位素数
+	p, err := rand.Prime(rand.Reader, 256)
+	if err != nil {
+		t.Fatalf("生成素数失败: %v", err)
+	}
+	secret, _ := rand.Int(rand.Reader, p)
+	indices := []Index{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4), big.NewInt(5)}
+
+	shares, err := SplitSecretShamir(p, 3, secret, indices)
+	if err != nil {
+		t.Fatalf("SplitSecretShamir 失败: %v", err)
+	}
+
+	t.Run("份额值在 [0, p) 内", func(t *testing.T) {
+		for i, s := range shares {
+			if s.Value.Sign() < 0 || s.Value.Cmp(p) >= 0 {
+				t.Errorf("share[%d] 超出范围", i)
+			}
+		}
+	})
+
+	t.Run("任意 3 个份额重建", func(t *testing.T) {
+		for _, subset := range [][]int{{0, 1, 2}, {1, 3, 4}, {4, 0, 2}} {
+			var sel Shares
+			for _, i := range subset {
+				sel = append(sel, shares[i])
+			}
+			got, err := ReconstructShamir(p, 3, sel)
+			if err != nil {
+				t.Fatalf("ReconstructShamir 失败: %v", err)
+			}
+			if got.Cmp(secret) != 0 {
+				t.Errorf("子集 %v: 期望 %v, 得到 %v", subset, secret, got)
+			}
+		}
+	})
+
+	t.Run("份额不足", func(t *testing.T) {
+		if _, err := ReconstructShamir(p, 3, shares[:2]); !errors.Is(err, ErrNotEnoughShares) {
+			t.Errorf("应该返回 ErrNotEnoughShares, 得到 %v", err)
+		}
+	})
+
+	t.Run("非法模数", func(t *testing.T) {
+		if _, err := SplitSecretShamir(big.NewInt(1), 2, secret, indices); !errors.Is(err, ErrInvalidModulus) {
+			t.Errorf("应该返回 ErrInvalidModulus, 得到 %v", err)
+		}
+		if _, err := ReconstructShamir(nil, 2, shares); !errors.Is(err, ErrInvalidModulus) {
+			t.Errorf("应该返回 ErrInvalidModulus, 得到 %v", err)
+		}
+	})
+
+	t.Run("索引按模数规范化", func(t *testing.T) {
+		dup := []Index{big.NewInt(1), new(big.Int).Add(p, big.NewInt(1))}
+		if _, err := SplitSecretShamir(p, 2, secret, dup); !errors.Is(err, ErrDuplicateIndex) {
+			t.Errorf("应该返回 ErrDuplicateIndex, 得到 %v", err)
+		}
+	})
+
+	t.Run("合数模数下区分零索引与不可逆索引", func(t *testing.T) {
+		composite := big.NewInt(15)
+		zero := []Index{big.NewInt(1), big.NewInt(30)}
+		if _, err := SplitSecretShamir(composite, 2, big.NewInt(7), zero); !errors.Is(err, ErrZeroIndex) {
+			t.Errorf("应该返回 ErrZeroIndex, 得到 %v", err)
+		}
+		notUnit := []Index{big.NewInt(1), big.NewInt(6)}
+		_, err := SplitSecretShamir(composite, 2, big.NewInt(7), notUnit)
+		if !errors.Is(err, ErrIndexNotUnit) || errors.Is(err, ErrZeroIndex) {
+			t.Errorf("应该返回 ErrIndexNotUnit, 得到 %v", err)
+		}
+		if _, err := SplitSecretShamir(composite, 2, big.NewInt(7), []Index{big.NewInt(1), big.NewInt(2)}); err != nil {
+			t.Errorf("与模数互素的索引应该被接受: %v", err)
+		}
+	})
+}